@@ -8,55 +8,600 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for PackageSystemItemUpdateStatus.
+const (
+	PackageSystemItemUpdateStatusApplicable  PackageSystemItemUpdateStatus = "Applicable"
+	PackageSystemItemUpdateStatusInstallable PackageSystemItemUpdateStatus = "Installable"
+	PackageSystemItemUpdateStatusNone        PackageSystemItemUpdateStatus = "None"
+)
+
+// Defines values for SystemPackageItemUpdateStatus.
+const (
+	SystemPackageItemUpdateStatusApplicable  SystemPackageItemUpdateStatus = "Applicable"
+	SystemPackageItemUpdateStatusInstallable SystemPackageItemUpdateStatus = "Installable"
+	SystemPackageItemUpdateStatusNone        SystemPackageItemUpdateStatus = "None"
+)
+
+// AdvisoriesPayload Page of advisories.
+type AdvisoriesPayload struct {
+	Data  []AdvisoryItem `json:"data"`
+	Links ListLinks      `json:"links"`
+	Meta  ListMeta       `json:"meta"`
+}
+
+// AdvisoryDetail defines model for AdvisoryDetail.
+type AdvisoryDetail struct {
+	AdvisoryType string    `json:"advisory_type"`
+	CveList      []string  `json:"cve_list"`
+	Description  string    `json:"description"`
+	Id           int64     `json:"id"`
+	ModifiedDate time.Time `json:"modified_date"`
+	Name         string    `json:"name"`
+
+	// PackageData Packages released by the advisory, as stored, null when the advisory has none.
+	PackageData        *interface{} `json:"package_data"`
+	PublicDate         time.Time    `json:"public_date"`
+	RebootRequired     bool         `json:"reboot_required"`
+	Severity           *int         `json:"severity"`
+	Solution           *string      `json:"solution"`
+	Summary            string       `json:"summary"`
+	Synopsis           string       `json:"synopsis"`
+	SystemsApplicable  int          `json:"systems_applicable"`
+	SystemsInstallable int          `json:"systems_installable"`
+	Url                *string      `json:"url"`
+}
+
+// AdvisoryItem defines model for AdvisoryItem.
+type AdvisoryItem struct {
+	AdvisoryType string     `json:"advisory_type"`
+	Id           int64      `json:"id"`
+	Name         string     `json:"name"`
+	PublicDate   *time.Time `json:"public_date"`
+	Severity     *int       `json:"severity"`
+	Synopsis     string     `json:"synopsis"`
+
+	// SystemsApplicable Systems the advisory applies to, including the installable ones.
+	SystemsApplicable  int `json:"systems_applicable"`
+	SystemsInstallable int `json:"systems_installable"`
+}
+
+// AdvisorySystemItem defines model for AdvisorySystemItem.
+type AdvisorySystemItem struct {
+	DisplayName   string             `json:"display_name"`
+	FirstReported time.Time          `json:"first_reported"`
+	InventoryId   openapi_types.UUID `json:"inventory_id"`
+	Status        string             `json:"status"`
+	StatusId      int                `json:"status_id"`
+}
+
+// AdvisorySystemsPayload Page of systems affected by an advisory.
+type AdvisorySystemsPayload struct {
+	Data  []AdvisorySystemItem `json:"data"`
+	Links ListLinks            `json:"links"`
+	Meta  ListMeta             `json:"meta"`
+}
+
+// ListLinks defines model for ListLinks.
+type ListLinks struct {
+	First string `json:"first"`
+
+	// Next Missing on the last page.
+	Next *string `json:"next,omitempty"`
+
+	// Previous Missing on the first page.
+	Previous *string `json:"previous,omitempty"`
+}
+
+// ListMeta defines model for ListMeta.
+type ListMeta struct {
+	// AccessAppliedAt When the access table applied access_revision, only reported by the materialized experiment on the package list.
+	AccessAppliedAt *time.Time `json:"access_applied_at,omitempty"`
+
+	// AccessRevision SpiceDB revision (ZedToken) of the access table the list was read with, only reported by the materialized experiment on the package list.
+	AccessRevision *string `json:"access_revision,omitempty"`
+
+	// Filter Filters in effect.
+	Filter map[string]string `json:"filter"`
+	Limit  int               `json:"limit"`
+
+	// PreFilterComparison Comparison of the package list page with the page of the host pre-filter, only reported by the patch-pre-filter experiment when the Compare-Pre-Filter header is true.
+	PreFilterComparison *PreFilterComparison `json:"pre_filter_comparison,omitempty"`
+
+	// QueryOptimization Join strategy selected by the Query-Optimization header, only reported by the pre-filter experiment on the package list.
+	QueryOptimization *string `json:"query_optimization,omitempty"`
+
+	// Sort Sort key, prefixed with - when descending.
+	Sort       string `json:"sort"`
+	TotalItems int64  `json:"total_items"`
+}
+
+// PackageItem A package of the package list. The keys are the package_account_data columns in snake_case, responses before the package names were added used the Go field names AccID, PkgNameID, SysInstalled, SysInstallable and SysApplicable.
+type PackageItem struct {
+	Description *string `json:"description"`
+
+	// LatestEvra Newest update available for the package on any of the systems counted.
+	LatestEvra         *string `json:"latest_evra"`
+	Name               string  `json:"name"`
+	PackageNameId      int64   `json:"package_name_id"`
+	RhAccountId        int     `json:"rh_account_id"`
+	Summary            *string `json:"summary"`
+	SystemsApplicable  int     `json:"systems_applicable"`
+	SystemsInstallable int     `json:"systems_installable"`
+	SystemsInstalled   int     `json:"systems_installed"`
+}
+
+// PackageSystemItem defines model for PackageSystemItem.
+type PackageSystemItem struct {
+	DisplayName   string             `json:"display_name"`
+	InstalledEvra string             `json:"installed_evra"`
+	InventoryId   openapi_types.UUID `json:"inventory_id"`
+
+	// LatestEvra Newest update available for the installed package, null when it is up to date.
+	LatestEvra   *string                       `json:"latest_evra"`
+	UpdateStatus PackageSystemItemUpdateStatus `json:"update_status"`
+}
+
+// PackageSystemItemUpdateStatus defines model for PackageSystemItem.UpdateStatus.
+type PackageSystemItemUpdateStatus string
+
+// PackageSystemsPayload Page of systems with a package installed.
+type PackageSystemsPayload struct {
+	Data  []PackageSystemItem `json:"data"`
+	Links ListLinks           `json:"links"`
+	Meta  ListMeta            `json:"meta"`
+}
+
+// PackageVersionItem defines model for PackageVersionItem.
+type PackageVersionItem struct {
+	Evra    string `json:"evra"`
+	Systems int    `json:"systems"`
+
+	// SystemsInstallable Systems on the version which have an installable update of the package.
+	SystemsInstallable int `json:"systems_installable"`
+}
+
+// PackageVersionsPayload Page of installed versions of a package.
+type PackageVersionsPayload struct {
+	Data  []PackageVersionItem `json:"data"`
+	Links ListLinks            `json:"links"`
+	Meta  ListMeta             `json:"meta"`
+}
+
+// PackagesPayload Page of packages.
+type PackagesPayload struct {
+	Data  []PackageItem `json:"data"`
+	Links ListLinks     `json:"links"`
+	Meta  ListMeta      `json:"meta"`
+}
+
+// PreFilterComparison Comparison of the package list page with the page of the host pre-filter, only reported by the patch-pre-filter experiment when the Compare-Pre-Filter header is true.
+type PreFilterComparison struct {
+	// CountMismatches Packages on both pages whose system counts differ.
+	CountMismatches int `json:"count_mismatches"`
+
+	// ExtraItems Packages of the page missing from the pre-filter page.
+	ExtraItems int `json:"extra_items"`
+
+	// LatencyMs Latency of the experiment's SpiceDB lookup and query.
+	LatencyMs float32 `json:"latency_ms"`
+
+	// MissingItems Packages of the pre-filter page missing from the page.
+	MissingItems int `json:"missing_items"`
+
+	// PreFilterLatencyMs Latency of the host pre-filter lookup and query.
+	PreFilterLatencyMs  float32 `json:"pre_filter_latency_ms"`
+	PreFilterTotalItems int64   `json:"pre_filter_total_items"`
+}
+
+// Problem RFC 7807 problem document.
+type Problem struct {
+	// Detail Explanation of this occurrence of the problem.
+	Detail *string `json:"detail,omitempty"`
+
+	// Status HTTP status code.
+	Status int `json:"status"`
+
+	// Title Short summary of the kind of problem.
+	Title string `json:"title"`
+
+	// Type URI reference identifying the kind of problem: /problems/validation, /problems/spicedb, /problems/database or about:blank.
+	Type string `json:"type"`
+}
+
+// RepoItem defines model for RepoItem.
+type RepoItem struct {
+	// Entitled Whether the organization is entitled to the content of the repository.
+	Entitled   bool   `json:"entitled"`
+	Id         int64  `json:"id"`
+	Name       string `json:"name"`
+	Systems    int    `json:"systems"`
+	ThirdParty bool   `json:"third_party"`
+}
+
+// RepoSystemsPayload Page of systems with a repository enabled.
+type RepoSystemsPayload struct {
+	Data  []SystemItem `json:"data"`
+	Links ListLinks    `json:"links"`
+	Meta  ListMeta     `json:"meta"`
+}
+
+// ReposPayload Page of repositories.
+type ReposPayload struct {
+	Data  []RepoItem `json:"data"`
+	Links ListLinks  `json:"links"`
+	Meta  ListMeta   `json:"meta"`
+}
+
+// SystemItem defines model for SystemItem.
+type SystemItem struct {
+	CulledTimestamp             *time.Time         `json:"culled_timestamp"`
+	DisplayName                 string             `json:"display_name"`
+	InstallableAdvisoryBugCount int                `json:"installable_advisory_bug_count"`
+	InstallableAdvisoryCount    int                `json:"installable_advisory_count"`
+	InstallableAdvisoryEnhCount int                `json:"installable_advisory_enh_count"`
+	InstallableAdvisorySecCount int                `json:"installable_advisory_sec_count"`
+	InventoryId                 openapi_types.UUID `json:"inventory_id"`
+	LastUpload                  *time.Time         `json:"last_upload"`
+	PackagesInstalled           int                `json:"packages_installed"`
+	PackagesUpdatable           int                `json:"packages_updatable"`
+	SatelliteManaged            bool               `json:"satellite_managed"`
+	Stale                       bool               `json:"stale"`
+	StaleTimestamp              *time.Time         `json:"stale_timestamp"`
+	StaleWarningTimestamp       *time.Time         `json:"stale_warning_timestamp"`
+	ThirdParty                  bool               `json:"third_party"`
+}
+
+// SystemPackageItem defines model for SystemPackageItem.
+type SystemPackageItem struct {
+	// Advisory Advisory which released the installed package.
+	Advisory     *string                       `json:"advisory"`
+	Evra         string                        `json:"evra"`
+	Name         string                        `json:"name"`
+	Summary      *string                       `json:"summary"`
+	UpdateStatus SystemPackageItemUpdateStatus `json:"update_status"`
+	Updates      []SystemPackageUpdate         `json:"updates"`
+}
+
+// SystemPackageItemUpdateStatus defines model for SystemPackageItem.UpdateStatus.
+type SystemPackageItemUpdateStatus string
+
+// SystemPackageUpdate defines model for SystemPackageUpdate.
+type SystemPackageUpdate struct {
+	Advisory string `json:"advisory"`
+	Evra     string `json:"evra"`
+	Status   string `json:"status"`
+}
+
+// SystemPackagesPayload Packages installed on a system.
+type SystemPackagesPayload struct {
+	Data        []SystemPackageItem `json:"data"`
+	InventoryId openapi_types.UUID  `json:"inventory_id"`
+}
+
+// SystemsPayload Page of systems.
+type SystemsPayload struct {
+	Data  []SystemItem `json:"data"`
+	Links ListLinks    `json:"links"`
+	Meta  ListMeta     `json:"meta"`
+}
+
+// BadRequest RFC 7807 problem document.
+type BadRequest = Problem
+
+// Forbidden RFC 7807 problem document.
+type Forbidden = Problem
+
+// InternalServerError RFC 7807 problem document.
+type InternalServerError = Problem
+
+// NotFound RFC 7807 problem document.
+type NotFound = Problem
+
+// ServiceUnavailable RFC 7807 problem document.
+type ServiceUnavailable = Problem
+
+// Unauthorized RFC 7807 problem document.
+type Unauthorized = Problem
+
+// GetContentAdvisoriesParams defines parameters for GetContentAdvisories.
+type GetContentAdvisoriesParams struct {
+	// Page Page number for advisories.
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Maximum number of advisories per page (1-100, default 20).
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Matches Matches all advisories whose name contains this parameter as a substring
+	Matches *string `form:"matches,omitempty" json:"matches,omitempty"`
+
+	// AdvisoryType Filter for advisories of a type, e.g. security, bugfix or enhancement.
+	AdvisoryType *string `form:"advisory_type,omitempty" json:"advisory_type,omitempty"`
+
+	// Severity Filter for advisories of a severity, 1 (Low) to 4 (Critical).
+	Severity *int `form:"severity,omitempty" json:"severity,omitempty"`
+
+	// PublicDateFrom Filter for advisories published at or after this time.
+	PublicDateFrom *time.Time `form:"public_date_from,omitempty" json:"public_date_from,omitempty"`
+
+	// PublicDateTo Filter for advisories published at or before this time.
+	PublicDateTo *time.Time `form:"public_date_to,omitempty" json:"public_date_to,omitempty"`
+
+	// SortKey The advisory key to sort by: name (default), synopsis, advisory_type, severity, public_date, systems_installable or systems_applicable.
+	SortKey *string `form:"sort_key,omitempty" json:"sort_key,omitempty"`
+
+	// SortOrder Sorting ascending (true) or descending (false).
+	SortOrder *bool `form:"sort_order,omitempty" json:"sort_order,omitempty"`
+}
+
+// GetContentAdvisoriesAdvisoryNameSystemsParams defines parameters for GetContentAdvisoriesAdvisoryNameSystems.
+type GetContentAdvisoriesAdvisoryNameSystemsParams struct {
+	// Page Page number for systems.
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Maximum number of systems per page (1-100, default 20).
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// StatusId Filter for systems the advisory is installable (0) or only applicable (1) on.
+	StatusId *int `form:"status_id,omitempty" json:"status_id,omitempty"`
+
+	// SortKey The system key to sort by: display_name (default), status_id or first_reported.
+	SortKey *string `form:"sort_key,omitempty" json:"sort_key,omitempty"`
+
+	// SortOrder Sorting ascending (true) or descending (false).
+	SortOrder *bool `form:"sort_order,omitempty" json:"sort_order,omitempty"`
+
+	// Filter System profile filters as filter[system_profile][field]...[operator]=value, evaluated against inventory.hosts.system_profile. The brackets give the path into the profile, optionally followed by one of the operators eq (default), neq, gt, gte, lt, lte, in (comma separated values), contains (array element), nil or not_nil. gt, gte, lt and lte only match fields holding a number. Several filters must all match.
+	Filter *struct {
+		SystemProfile *map[string]interface{} `json:"system_profile,omitempty"`
+	} `json:"filter,omitempty"`
+}
+
 // GetContentPackagesParams defines parameters for GetContentPackages.
 type GetContentPackagesParams struct {
 	// Page Page number for packages.
 	Page *int `form:"page,omitempty" json:"page,omitempty"`
 
+	// Limit Maximum number of packages per page (1-100, default 20).
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor taken from the next or previous link of a previous response. Cannot be combined with page.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
 	// Matches Matches all packages whose name contains this parameter as a substring
 	Matches *string `form:"matches,omitempty" json:"matches,omitempty"`
 
 	// PatchesAvailable Filter for packages for which there are systems with patches available (true) or already up to date (false).
 	PatchesAvailable *bool `form:"patches_available,omitempty" json:"patches_available,omitempty"`
 
-	// Tag Filter package by tag
-	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+	// Tag Filter packages by tags of the systems they are installed on, in the form namespace/key=value. Repeat the parameter to require several tags.
+	Tag *[]string `form:"tag,omitempty" json:"tag,omitempty"`
 
 	// SortKey The package key to sort by.
 	SortKey *string `form:"sort_key,omitempty" json:"sort_key,omitempty"`
 
 	// SortOrder Sorting ascending (true) or descending (false).
 	SortOrder *bool `form:"sort_order,omitempty" json:"sort_order,omitempty"`
+
+	// Filter System profile filters as filter[system_profile][field]...[operator]=value, evaluated against inventory.hosts.system_profile. The brackets give the path into the profile, optionally followed by one of the operators eq (default), neq, gt, gte, lt, lte, in (comma separated values), contains (array element), nil or not_nil. gt, gte, lt and lte only match fields holding a number. Several filters must all match.
+	Filter *struct {
+		SystemProfile *map[string]interface{} `json:"system_profile,omitempty"`
+	} `json:"filter,omitempty"`
+}
+
+// GetContentPackagesPackageNameSystemsParams defines parameters for GetContentPackagesPackageNameSystems.
+type GetContentPackagesPackageNameSystemsParams struct {
+	// Page Page number for systems.
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Maximum number of systems per page (1-100, default 20).
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// PatchesAvailable Filter for systems with patches available for the package (true) or already up to date (false).
+	PatchesAvailable *bool `form:"patches_available,omitempty" json:"patches_available,omitempty"`
+
+	// SortKey The system key to sort by: display_name (default) or installed_evra.
+	SortKey *string `form:"sort_key,omitempty" json:"sort_key,omitempty"`
+
+	// SortOrder Sorting ascending (true) or descending (false).
+	SortOrder *bool `form:"sort_order,omitempty" json:"sort_order,omitempty"`
+
+	// Filter System profile filters as filter[system_profile][field]...[operator]=value, evaluated against inventory.hosts.system_profile. The brackets give the path into the profile, optionally followed by one of the operators eq (default), neq, gt, gte, lt, lte, in (comma separated values), contains (array element), nil or not_nil. gt, gte, lt and lte only match fields holding a number. Several filters must all match.
+	Filter *struct {
+		SystemProfile *map[string]interface{} `json:"system_profile,omitempty"`
+	} `json:"filter,omitempty"`
+}
+
+// GetContentPackagesPackageNameVersionsParams defines parameters for GetContentPackagesPackageNameVersions.
+type GetContentPackagesPackageNameVersionsParams struct {
+	// Page Page number for versions.
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Maximum number of versions per page (1-100, default 20).
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// SortKey The version key to sort by: evra (default), systems or systems_installable.
+	SortKey *string `form:"sort_key,omitempty" json:"sort_key,omitempty"`
+
+	// SortOrder Sorting ascending (true) or descending (false).
+	SortOrder *bool `form:"sort_order,omitempty" json:"sort_order,omitempty"`
+}
+
+// GetContentReposParams defines parameters for GetContentRepos.
+type GetContentReposParams struct {
+	// Page Page number for repositories.
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Maximum number of repositories per page (1-100, default 20).
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Matches Matches all repositories whose name contains this parameter as a substring
+	Matches *string `form:"matches,omitempty" json:"matches,omitempty"`
+
+	// ThirdParty Filter for third party (true) or Red Hat (false) repositories.
+	ThirdParty *bool `form:"third_party,omitempty" json:"third_party,omitempty"`
+
+	// SortKey The repository key to sort by: name (default), third_party or systems.
+	SortKey *string `form:"sort_key,omitempty" json:"sort_key,omitempty"`
+
+	// SortOrder Sorting ascending (true) or descending (false).
+	SortOrder *bool `form:"sort_order,omitempty" json:"sort_order,omitempty"`
+}
+
+// GetContentReposRepoIdSystemsParams defines parameters for GetContentReposRepoIdSystems.
+type GetContentReposRepoIdSystemsParams struct {
+	// Page Page number for systems.
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Maximum number of systems per page (1-100, default 20).
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// SortKey The system key to sort by, any of the system fields (default display_name).
+	SortKey *string `form:"sort_key,omitempty" json:"sort_key,omitempty"`
+
+	// SortOrder Sorting ascending (true) or descending (false).
+	SortOrder *bool `form:"sort_order,omitempty" json:"sort_order,omitempty"`
+
+	// Filter System profile filters as filter[system_profile][field]...[operator]=value, evaluated against inventory.hosts.system_profile. The brackets give the path into the profile, optionally followed by one of the operators eq (default), neq, gt, gte, lt, lte, in (comma separated values), contains (array element), nil or not_nil. gt, gte, lt and lte only match fields holding a number. Several filters must all match.
+	Filter *struct {
+		SystemProfile *map[string]interface{} `json:"system_profile,omitempty"`
+	} `json:"filter,omitempty"`
+}
+
+// GetContentSystemsParams defines parameters for GetContentSystems.
+type GetContentSystemsParams struct {
+	// Page Page number for systems.
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Maximum number of systems per page (1-100, default 20).
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Matches Matches all systems whose display name contains this parameter as a substring
+	Matches *string `form:"matches,omitempty" json:"matches,omitempty"`
+
+	// Stale Filter for stale (true) or fresh (false) systems.
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty"`
+
+	// ThirdParty Filter for systems with (true) or without (false) third party content.
+	ThirdParty *bool `form:"third_party,omitempty" json:"third_party,omitempty"`
+
+	// SatelliteManaged Filter for systems managed (true) or not managed (false) by Satellite.
+	SatelliteManaged *bool `form:"satellite_managed,omitempty" json:"satellite_managed,omitempty"`
+
+	// SortKey The system key to sort by, any of the system fields (default display_name).
+	SortKey *string `form:"sort_key,omitempty" json:"sort_key,omitempty"`
+
+	// SortOrder Sorting ascending (true) or descending (false).
+	SortOrder *bool `form:"sort_order,omitempty" json:"sort_order,omitempty"`
+
+	// Filter System profile filters as filter[system_profile][field]...[operator]=value, evaluated against inventory.hosts.system_profile. The brackets give the path into the profile, optionally followed by one of the operators eq (default), neq, gt, gte, lt, lte, in (comma separated values), contains (array element), nil or not_nil. gt, gte, lt and lte only match fields holding a number. Several filters must all match.
+	Filter *struct {
+		SystemProfile *map[string]interface{} `json:"system_profile,omitempty"`
+	} `json:"filter,omitempty"`
 }
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (GET /content/advisories)
+	GetContentAdvisories(w http.ResponseWriter, r *http.Request, params GetContentAdvisoriesParams)
+
+	// (GET /content/advisories/{advisory_name})
+	GetContentAdvisoriesAdvisoryName(w http.ResponseWriter, r *http.Request, advisoryName string)
+
+	// (GET /content/advisories/{advisory_name}/systems)
+	GetContentAdvisoriesAdvisoryNameSystems(w http.ResponseWriter, r *http.Request, advisoryName string, params GetContentAdvisoriesAdvisoryNameSystemsParams)
+
 	// (GET /content/packages)
 	GetContentPackages(w http.ResponseWriter, r *http.Request, params GetContentPackagesParams)
+
+	// (GET /content/packages/{package_name}/systems)
+	GetContentPackagesPackageNameSystems(w http.ResponseWriter, r *http.Request, packageName string, params GetContentPackagesPackageNameSystemsParams)
+
+	// (GET /content/packages/{package_name}/versions)
+	GetContentPackagesPackageNameVersions(w http.ResponseWriter, r *http.Request, packageName string, params GetContentPackagesPackageNameVersionsParams)
+
+	// (GET /content/repos)
+	GetContentRepos(w http.ResponseWriter, r *http.Request, params GetContentReposParams)
+
+	// (GET /content/repos/{repo_id}/systems)
+	GetContentReposRepoIdSystems(w http.ResponseWriter, r *http.Request, repoId int64, params GetContentReposRepoIdSystemsParams)
+
+	// (GET /content/systems)
+	GetContentSystems(w http.ResponseWriter, r *http.Request, params GetContentSystemsParams)
+
+	// (GET /systems/{inventory_id}/packages)
+	GetSystemsInventoryIdPackages(w http.ResponseWriter, r *http.Request, inventoryId string)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// (GET /content/advisories)
+func (_ Unimplemented) GetContentAdvisories(w http.ResponseWriter, r *http.Request, params GetContentAdvisoriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /content/advisories/{advisory_name})
+func (_ Unimplemented) GetContentAdvisoriesAdvisoryName(w http.ResponseWriter, r *http.Request, advisoryName string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /content/advisories/{advisory_name}/systems)
+func (_ Unimplemented) GetContentAdvisoriesAdvisoryNameSystems(w http.ResponseWriter, r *http.Request, advisoryName string, params GetContentAdvisoriesAdvisoryNameSystemsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (GET /content/packages)
 func (_ Unimplemented) GetContentPackages(w http.ResponseWriter, r *http.Request, params GetContentPackagesParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (GET /content/packages/{package_name}/systems)
+func (_ Unimplemented) GetContentPackagesPackageNameSystems(w http.ResponseWriter, r *http.Request, packageName string, params GetContentPackagesPackageNameSystemsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /content/packages/{package_name}/versions)
+func (_ Unimplemented) GetContentPackagesPackageNameVersions(w http.ResponseWriter, r *http.Request, packageName string, params GetContentPackagesPackageNameVersionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /content/repos)
+func (_ Unimplemented) GetContentRepos(w http.ResponseWriter, r *http.Request, params GetContentReposParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /content/repos/{repo_id}/systems)
+func (_ Unimplemented) GetContentReposRepoIdSystems(w http.ResponseWriter, r *http.Request, repoId int64, params GetContentReposRepoIdSystemsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /content/systems)
+func (_ Unimplemented) GetContentSystems(w http.ResponseWriter, r *http.Request, params GetContentSystemsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /systems/{inventory_id}/packages)
+func (_ Unimplemented) GetSystemsInventoryIdPackages(w http.ResponseWriter, r *http.Request, inventoryId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -66,14 +611,14 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// GetContentPackages operation middleware
-func (siw *ServerInterfaceWrapper) GetContentPackages(w http.ResponseWriter, r *http.Request) {
+// GetContentAdvisories operation middleware
+func (siw *ServerInterfaceWrapper) GetContentAdvisories(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetContentPackagesParams
+	var params GetContentAdvisoriesParams
 
 	// ------------- Optional query parameter "page" -------------
 
@@ -83,6 +628,14 @@ func (siw *ServerInterfaceWrapper) GetContentPackages(w http.ResponseWriter, r *
 		return
 	}
 
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
 	// ------------- Optional query parameter "matches" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "matches", r.URL.Query(), &params.Matches)
@@ -91,19 +644,35 @@ func (siw *ServerInterfaceWrapper) GetContentPackages(w http.ResponseWriter, r *
 		return
 	}
 
-	// ------------- Optional query parameter "patches_available" -------------
+	// ------------- Optional query parameter "advisory_type" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "patches_available", r.URL.Query(), &params.PatchesAvailable)
+	err = runtime.BindQueryParameter("form", true, false, "advisory_type", r.URL.Query(), &params.AdvisoryType)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "patches_available", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "advisory_type", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "tag" -------------
+	// ------------- Optional query parameter "severity" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	err = runtime.BindQueryParameter("form", true, false, "severity", r.URL.Query(), &params.Severity)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "severity", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "public_date_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "public_date_from", r.URL.Query(), &params.PublicDateFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "public_date_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "public_date_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "public_date_to", r.URL.Query(), &params.PublicDateTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "public_date_to", Err: err})
 		return
 	}
 
@@ -124,7 +693,7 @@ func (siw *ServerInterfaceWrapper) GetContentPackages(w http.ResponseWriter, r *
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetContentPackages(w, r, params)
+		siw.Handler.GetContentAdvisories(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -134,139 +703,1553 @@ func (siw *ServerInterfaceWrapper) GetContentPackages(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// GetContentAdvisoriesAdvisoryName operation middleware
+func (siw *ServerInterfaceWrapper) GetContentAdvisoriesAdvisoryName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "advisory_name" -------------
+	var advisoryName string
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "advisory_name", runtime.ParamLocationPath, chi.URLParam(r, "advisory_name"), &advisoryName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "advisory_name", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetContentAdvisoriesAdvisoryName(w, r, advisoryName)
+	}))
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type RequiredParamError struct {
-	ParamName string
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (e *RequiredParamError) Error() string {
+// GetContentAdvisoriesAdvisoryNameSystems operation middleware
+func (siw *ServerInterfaceWrapper) GetContentAdvisoriesAdvisoryNameSystems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "advisory_name" -------------
+	var advisoryName string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "advisory_name", runtime.ParamLocationPath, chi.URLParam(r, "advisory_name"), &advisoryName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "advisory_name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetContentAdvisoriesAdvisoryNameSystemsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status_id", r.URL.Query(), &params.StatusId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_key" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_key", r.URL.Query(), &params.SortKey)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_order", r.URL.Query(), &params.SortOrder)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("deepObject", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetContentAdvisoriesAdvisoryNameSystems(w, r, advisoryName, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetContentPackages operation middleware
+func (siw *ServerInterfaceWrapper) GetContentPackages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetContentPackagesParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "matches" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "matches", r.URL.Query(), &params.Matches)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "matches", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "patches_available" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "patches_available", r.URL.Query(), &params.PatchesAvailable)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "patches_available", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_key" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_key", r.URL.Query(), &params.SortKey)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_order", r.URL.Query(), &params.SortOrder)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("deepObject", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetContentPackages(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetContentPackagesPackageNameSystems operation middleware
+func (siw *ServerInterfaceWrapper) GetContentPackagesPackageNameSystems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "package_name" -------------
+	var packageName string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "package_name", runtime.ParamLocationPath, chi.URLParam(r, "package_name"), &packageName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "package_name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetContentPackagesPackageNameSystemsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "patches_available" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "patches_available", r.URL.Query(), &params.PatchesAvailable)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "patches_available", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_key" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_key", r.URL.Query(), &params.SortKey)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_order", r.URL.Query(), &params.SortOrder)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("deepObject", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetContentPackagesPackageNameSystems(w, r, packageName, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetContentPackagesPackageNameVersions operation middleware
+func (siw *ServerInterfaceWrapper) GetContentPackagesPackageNameVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "package_name" -------------
+	var packageName string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "package_name", runtime.ParamLocationPath, chi.URLParam(r, "package_name"), &packageName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "package_name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetContentPackagesPackageNameVersionsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_key" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_key", r.URL.Query(), &params.SortKey)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_order", r.URL.Query(), &params.SortOrder)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_order", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetContentPackagesPackageNameVersions(w, r, packageName, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetContentRepos operation middleware
+func (siw *ServerInterfaceWrapper) GetContentRepos(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetContentReposParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "matches" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "matches", r.URL.Query(), &params.Matches)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "matches", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "third_party" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "third_party", r.URL.Query(), &params.ThirdParty)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "third_party", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_key" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_key", r.URL.Query(), &params.SortKey)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_order", r.URL.Query(), &params.SortOrder)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_order", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetContentRepos(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetContentReposRepoIdSystems operation middleware
+func (siw *ServerInterfaceWrapper) GetContentReposRepoIdSystems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo_id" -------------
+	var repoId int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "repo_id", runtime.ParamLocationPath, chi.URLParam(r, "repo_id"), &repoId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetContentReposRepoIdSystemsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_key" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_key", r.URL.Query(), &params.SortKey)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_order", r.URL.Query(), &params.SortOrder)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("deepObject", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetContentReposRepoIdSystems(w, r, repoId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetContentSystems operation middleware
+func (siw *ServerInterfaceWrapper) GetContentSystems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetContentSystemsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "matches" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "matches", r.URL.Query(), &params.Matches)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "matches", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "stale" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "stale", r.URL.Query(), &params.Stale)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "stale", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "third_party" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "third_party", r.URL.Query(), &params.ThirdParty)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "third_party", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "satellite_managed" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "satellite_managed", r.URL.Query(), &params.SatelliteManaged)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "satellite_managed", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_key" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_key", r.URL.Query(), &params.SortKey)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_order", r.URL.Query(), &params.SortOrder)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("deepObject", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetContentSystems(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetSystemsInventoryIdPackages operation middleware
+func (siw *ServerInterfaceWrapper) GetSystemsInventoryIdPackages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "inventory_id" -------------
+	var inventoryId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "inventory_id", runtime.ParamLocationPath, chi.URLParam(r, "inventory_id"), &inventoryId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "inventory_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSystemsInventoryIdPackages(w, r, inventoryId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
 	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
 }
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/content/advisories", wrapper.GetContentAdvisories)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/content/advisories/{advisory_name}", wrapper.GetContentAdvisoriesAdvisoryName)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/content/advisories/{advisory_name}/systems", wrapper.GetContentAdvisoriesAdvisoryNameSystems)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/content/packages", wrapper.GetContentPackages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/content/packages/{package_name}/systems", wrapper.GetContentPackagesPackageNameSystems)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/content/packages/{package_name}/versions", wrapper.GetContentPackagesPackageNameVersions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/content/repos", wrapper.GetContentRepos)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/content/repos/{repo_id}/systems", wrapper.GetContentReposRepoIdSystems)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/content/systems", wrapper.GetContentSystems)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/systems/{inventory_id}/packages", wrapper.GetSystemsInventoryIdPackages)
+	})
+
+	return r
+}
+
+type BadRequestApplicationProblemPlusJSONResponse Problem
+
+type ForbiddenApplicationProblemPlusJSONResponse Problem
+
+type InternalServerErrorApplicationProblemPlusJSONResponse Problem
+
+type NotFoundApplicationProblemPlusJSONResponse Problem
+
+type ServiceUnavailableApplicationProblemPlusJSONResponse Problem
+
+type UnauthorizedApplicationProblemPlusJSONResponse Problem
+
+type GetContentAdvisoriesRequestObject struct {
+	Params GetContentAdvisoriesParams
+}
+
+type GetContentAdvisoriesResponseObject interface {
+	VisitGetContentAdvisoriesResponse(w http.ResponseWriter) error
+}
+
+type GetContentAdvisories200JSONResponse AdvisoriesPayload
+
+func (response GetContentAdvisories200JSONResponse) VisitGetContentAdvisoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisories400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisories400ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisories401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisories401ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisories403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisories403ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisories500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisories500ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisories503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisories503ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryNameRequestObject struct {
+	AdvisoryName string `json:"advisory_name"`
+}
+
+type GetContentAdvisoriesAdvisoryNameResponseObject interface {
+	VisitGetContentAdvisoriesAdvisoryNameResponse(w http.ResponseWriter) error
+}
+
+type GetContentAdvisoriesAdvisoryName200JSONResponse AdvisoryDetail
+
+func (response GetContentAdvisoriesAdvisoryName200JSONResponse) VisitGetContentAdvisoriesAdvisoryNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryName400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisoriesAdvisoryName400ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesAdvisoryNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryName401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisoriesAdvisoryName401ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesAdvisoryNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryName403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisoriesAdvisoryName403ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesAdvisoryNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryName404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisoriesAdvisoryName404ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesAdvisoryNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryName500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisoriesAdvisoryName500ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesAdvisoryNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryName503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisoriesAdvisoryName503ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesAdvisoryNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryNameSystemsRequestObject struct {
+	AdvisoryName string `json:"advisory_name"`
+	Params       GetContentAdvisoriesAdvisoryNameSystemsParams
+}
+
+type GetContentAdvisoriesAdvisoryNameSystemsResponseObject interface {
+	VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w http.ResponseWriter) error
+}
+
+type GetContentAdvisoriesAdvisoryNameSystems200JSONResponse AdvisorySystemsPayload
+
+func (response GetContentAdvisoriesAdvisoryNameSystems200JSONResponse) VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryNameSystems400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisoriesAdvisoryNameSystems400ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryNameSystems401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisoriesAdvisoryNameSystems401ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryNameSystems403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisoriesAdvisoryNameSystems403ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryNameSystems404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisoriesAdvisoryNameSystems404ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryNameSystems500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisoriesAdvisoryNameSystems500ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentAdvisoriesAdvisoryNameSystems503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentAdvisoriesAdvisoryNameSystems503ApplicationProblemPlusJSONResponse) VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesRequestObject struct {
+	Params GetContentPackagesParams
+}
+
+type GetContentPackagesResponseObject interface {
+	VisitGetContentPackagesResponse(w http.ResponseWriter) error
+}
+
+type GetContentPackages200JSONResponse PackagesPayload
+
+func (response GetContentPackages200JSONResponse) VisitGetContentPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackages400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackages400ApplicationProblemPlusJSONResponse) VisitGetContentPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackages401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackages401ApplicationProblemPlusJSONResponse) VisitGetContentPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackages403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackages403ApplicationProblemPlusJSONResponse) VisitGetContentPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackages500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackages500ApplicationProblemPlusJSONResponse) VisitGetContentPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackages503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackages503ApplicationProblemPlusJSONResponse) VisitGetContentPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesPackageNameSystemsRequestObject struct {
+	PackageName string `json:"package_name"`
+	Params      GetContentPackagesPackageNameSystemsParams
+}
+
+type GetContentPackagesPackageNameSystemsResponseObject interface {
+	VisitGetContentPackagesPackageNameSystemsResponse(w http.ResponseWriter) error
+}
+
+type GetContentPackagesPackageNameSystems200JSONResponse PackageSystemsPayload
+
+func (response GetContentPackagesPackageNameSystems200JSONResponse) VisitGetContentPackagesPackageNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesPackageNameSystems400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackagesPackageNameSystems400ApplicationProblemPlusJSONResponse) VisitGetContentPackagesPackageNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesPackageNameSystems401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackagesPackageNameSystems401ApplicationProblemPlusJSONResponse) VisitGetContentPackagesPackageNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesPackageNameSystems403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackagesPackageNameSystems403ApplicationProblemPlusJSONResponse) VisitGetContentPackagesPackageNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesPackageNameSystems500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackagesPackageNameSystems500ApplicationProblemPlusJSONResponse) VisitGetContentPackagesPackageNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesPackageNameSystems503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackagesPackageNameSystems503ApplicationProblemPlusJSONResponse) VisitGetContentPackagesPackageNameSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesPackageNameVersionsRequestObject struct {
+	PackageName string `json:"package_name"`
+	Params      GetContentPackagesPackageNameVersionsParams
+}
+
+type GetContentPackagesPackageNameVersionsResponseObject interface {
+	VisitGetContentPackagesPackageNameVersionsResponse(w http.ResponseWriter) error
+}
+
+type GetContentPackagesPackageNameVersions200JSONResponse PackageVersionsPayload
+
+func (response GetContentPackagesPackageNameVersions200JSONResponse) VisitGetContentPackagesPackageNameVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesPackageNameVersions400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackagesPackageNameVersions400ApplicationProblemPlusJSONResponse) VisitGetContentPackagesPackageNameVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesPackageNameVersions401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackagesPackageNameVersions401ApplicationProblemPlusJSONResponse) VisitGetContentPackagesPackageNameVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesPackageNameVersions403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackagesPackageNameVersions403ApplicationProblemPlusJSONResponse) VisitGetContentPackagesPackageNameVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesPackageNameVersions500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackagesPackageNameVersions500ApplicationProblemPlusJSONResponse) VisitGetContentPackagesPackageNameVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentPackagesPackageNameVersions503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentPackagesPackageNameVersions503ApplicationProblemPlusJSONResponse) VisitGetContentPackagesPackageNameVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentReposRequestObject struct {
+	Params GetContentReposParams
+}
+
+type GetContentReposResponseObject interface {
+	VisitGetContentReposResponse(w http.ResponseWriter) error
+}
+
+type GetContentRepos200JSONResponse ReposPayload
+
+func (response GetContentRepos200JSONResponse) VisitGetContentReposResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentRepos400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentRepos400ApplicationProblemPlusJSONResponse) VisitGetContentReposResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentRepos401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentRepos401ApplicationProblemPlusJSONResponse) VisitGetContentReposResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentRepos403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentRepos403ApplicationProblemPlusJSONResponse) VisitGetContentReposResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentRepos500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentRepos500ApplicationProblemPlusJSONResponse) VisitGetContentReposResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentRepos503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentRepos503ApplicationProblemPlusJSONResponse) VisitGetContentReposResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentReposRepoIdSystemsRequestObject struct {
+	RepoId int64 `json:"repo_id"`
+	Params GetContentReposRepoIdSystemsParams
+}
+
+type GetContentReposRepoIdSystemsResponseObject interface {
+	VisitGetContentReposRepoIdSystemsResponse(w http.ResponseWriter) error
+}
+
+type GetContentReposRepoIdSystems200JSONResponse RepoSystemsPayload
+
+func (response GetContentReposRepoIdSystems200JSONResponse) VisitGetContentReposRepoIdSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentReposRepoIdSystems400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentReposRepoIdSystems400ApplicationProblemPlusJSONResponse) VisitGetContentReposRepoIdSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentReposRepoIdSystems401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentReposRepoIdSystems401ApplicationProblemPlusJSONResponse) VisitGetContentReposRepoIdSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentReposRepoIdSystems403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentReposRepoIdSystems403ApplicationProblemPlusJSONResponse) VisitGetContentReposRepoIdSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentReposRepoIdSystems404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentReposRepoIdSystems404ApplicationProblemPlusJSONResponse) VisitGetContentReposRepoIdSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentReposRepoIdSystems500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentReposRepoIdSystems500ApplicationProblemPlusJSONResponse) VisitGetContentReposRepoIdSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentReposRepoIdSystems503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentReposRepoIdSystems503ApplicationProblemPlusJSONResponse) VisitGetContentReposRepoIdSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentSystemsRequestObject struct {
+	Params GetContentSystemsParams
+}
+
+type GetContentSystemsResponseObject interface {
+	VisitGetContentSystemsResponse(w http.ResponseWriter) error
+}
+
+type GetContentSystems200JSONResponse SystemsPayload
+
+func (response GetContentSystems200JSONResponse) VisitGetContentSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentSystems400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentSystems400ApplicationProblemPlusJSONResponse) VisitGetContentSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentSystems401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentSystems401ApplicationProblemPlusJSONResponse) VisitGetContentSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentSystems403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetContentSystems403ApplicationProblemPlusJSONResponse) VisitGetContentSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetContentSystems500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
 }
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+func (response GetContentSystems500ApplicationProblemPlusJSONResponse) VisitGetContentSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
+type GetContentSystems503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
 }
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
+func (response GetContentSystems503ApplicationProblemPlusJSONResponse) VisitGetContentSystemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+type GetSystemsInventoryIdPackagesRequestObject struct {
+	InventoryId string `json:"inventory_id"`
 }
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+type GetSystemsInventoryIdPackagesResponseObject interface {
+	VisitGetSystemsInventoryIdPackagesResponse(w http.ResponseWriter) error
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+type GetSystemsInventoryIdPackages200JSONResponse SystemPackagesPayload
+
+func (response GetSystemsInventoryIdPackages200JSONResponse) VisitGetSystemsInventoryIdPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+type GetSystemsInventoryIdPackages400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
+func (response GetSystemsInventoryIdPackages400ApplicationProblemPlusJSONResponse) VisitGetSystemsInventoryIdPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+type GetSystemsInventoryIdPackages401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
+func (response GetSystemsInventoryIdPackages401ApplicationProblemPlusJSONResponse) VisitGetSystemsInventoryIdPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+type GetSystemsInventoryIdPackages403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+func (response GetSystemsInventoryIdPackages403ApplicationProblemPlusJSONResponse) VisitGetSystemsInventoryIdPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/content/packages", wrapper.GetContentPackages)
-	})
+type GetSystemsInventoryIdPackages404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
 
-	return r
+func (response GetSystemsInventoryIdPackages404ApplicationProblemPlusJSONResponse) VisitGetSystemsInventoryIdPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetContentPackagesRequestObject struct {
-	Params GetContentPackagesParams
+type GetSystemsInventoryIdPackages500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
 }
 
-type GetContentPackagesResponseObject interface {
-	VisitGetContentPackagesResponse(w http.ResponseWriter) error
+func (response GetSystemsInventoryIdPackages500ApplicationProblemPlusJSONResponse) VisitGetSystemsInventoryIdPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSystemsInventoryIdPackages503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetSystemsInventoryIdPackages503ApplicationProblemPlusJSONResponse) VisitGetSystemsInventoryIdPackagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 
+	// (GET /content/advisories)
+	GetContentAdvisories(ctx context.Context, request GetContentAdvisoriesRequestObject) (GetContentAdvisoriesResponseObject, error)
+
+	// (GET /content/advisories/{advisory_name})
+	GetContentAdvisoriesAdvisoryName(ctx context.Context, request GetContentAdvisoriesAdvisoryNameRequestObject) (GetContentAdvisoriesAdvisoryNameResponseObject, error)
+
+	// (GET /content/advisories/{advisory_name}/systems)
+	GetContentAdvisoriesAdvisoryNameSystems(ctx context.Context, request GetContentAdvisoriesAdvisoryNameSystemsRequestObject) (GetContentAdvisoriesAdvisoryNameSystemsResponseObject, error)
+
 	// (GET /content/packages)
 	GetContentPackages(ctx context.Context, request GetContentPackagesRequestObject) (GetContentPackagesResponseObject, error)
+
+	// (GET /content/packages/{package_name}/systems)
+	GetContentPackagesPackageNameSystems(ctx context.Context, request GetContentPackagesPackageNameSystemsRequestObject) (GetContentPackagesPackageNameSystemsResponseObject, error)
+
+	// (GET /content/packages/{package_name}/versions)
+	GetContentPackagesPackageNameVersions(ctx context.Context, request GetContentPackagesPackageNameVersionsRequestObject) (GetContentPackagesPackageNameVersionsResponseObject, error)
+
+	// (GET /content/repos)
+	GetContentRepos(ctx context.Context, request GetContentReposRequestObject) (GetContentReposResponseObject, error)
+
+	// (GET /content/repos/{repo_id}/systems)
+	GetContentReposRepoIdSystems(ctx context.Context, request GetContentReposRepoIdSystemsRequestObject) (GetContentReposRepoIdSystemsResponseObject, error)
+
+	// (GET /content/systems)
+	GetContentSystems(ctx context.Context, request GetContentSystemsRequestObject) (GetContentSystemsResponseObject, error)
+
+	// (GET /systems/{inventory_id}/packages)
+	GetSystemsInventoryIdPackages(ctx context.Context, request GetSystemsInventoryIdPackagesRequestObject) (GetSystemsInventoryIdPackagesResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHttpHandlerFunc
@@ -298,6 +2281,85 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// GetContentAdvisories operation middleware
+func (sh *strictHandler) GetContentAdvisories(w http.ResponseWriter, r *http.Request, params GetContentAdvisoriesParams) {
+	var request GetContentAdvisoriesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetContentAdvisories(ctx, request.(GetContentAdvisoriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetContentAdvisories")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetContentAdvisoriesResponseObject); ok {
+		if err := validResponse.VisitGetContentAdvisoriesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetContentAdvisoriesAdvisoryName operation middleware
+func (sh *strictHandler) GetContentAdvisoriesAdvisoryName(w http.ResponseWriter, r *http.Request, advisoryName string) {
+	var request GetContentAdvisoriesAdvisoryNameRequestObject
+
+	request.AdvisoryName = advisoryName
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetContentAdvisoriesAdvisoryName(ctx, request.(GetContentAdvisoriesAdvisoryNameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetContentAdvisoriesAdvisoryName")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetContentAdvisoriesAdvisoryNameResponseObject); ok {
+		if err := validResponse.VisitGetContentAdvisoriesAdvisoryNameResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetContentAdvisoriesAdvisoryNameSystems operation middleware
+func (sh *strictHandler) GetContentAdvisoriesAdvisoryNameSystems(w http.ResponseWriter, r *http.Request, advisoryName string, params GetContentAdvisoriesAdvisoryNameSystemsParams) {
+	var request GetContentAdvisoriesAdvisoryNameSystemsRequestObject
+
+	request.AdvisoryName = advisoryName
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetContentAdvisoriesAdvisoryNameSystems(ctx, request.(GetContentAdvisoriesAdvisoryNameSystemsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetContentAdvisoriesAdvisoryNameSystems")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetContentAdvisoriesAdvisoryNameSystemsResponseObject); ok {
+		if err := validResponse.VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetContentPackages operation middleware
 func (sh *strictHandler) GetContentPackages(w http.ResponseWriter, r *http.Request, params GetContentPackagesParams) {
 	var request GetContentPackagesRequestObject
@@ -324,18 +2386,239 @@ func (sh *strictHandler) GetContentPackages(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// GetContentPackagesPackageNameSystems operation middleware
+func (sh *strictHandler) GetContentPackagesPackageNameSystems(w http.ResponseWriter, r *http.Request, packageName string, params GetContentPackagesPackageNameSystemsParams) {
+	var request GetContentPackagesPackageNameSystemsRequestObject
+
+	request.PackageName = packageName
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetContentPackagesPackageNameSystems(ctx, request.(GetContentPackagesPackageNameSystemsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetContentPackagesPackageNameSystems")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetContentPackagesPackageNameSystemsResponseObject); ok {
+		if err := validResponse.VisitGetContentPackagesPackageNameSystemsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetContentPackagesPackageNameVersions operation middleware
+func (sh *strictHandler) GetContentPackagesPackageNameVersions(w http.ResponseWriter, r *http.Request, packageName string, params GetContentPackagesPackageNameVersionsParams) {
+	var request GetContentPackagesPackageNameVersionsRequestObject
+
+	request.PackageName = packageName
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetContentPackagesPackageNameVersions(ctx, request.(GetContentPackagesPackageNameVersionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetContentPackagesPackageNameVersions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetContentPackagesPackageNameVersionsResponseObject); ok {
+		if err := validResponse.VisitGetContentPackagesPackageNameVersionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetContentRepos operation middleware
+func (sh *strictHandler) GetContentRepos(w http.ResponseWriter, r *http.Request, params GetContentReposParams) {
+	var request GetContentReposRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetContentRepos(ctx, request.(GetContentReposRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetContentRepos")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetContentReposResponseObject); ok {
+		if err := validResponse.VisitGetContentReposResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetContentReposRepoIdSystems operation middleware
+func (sh *strictHandler) GetContentReposRepoIdSystems(w http.ResponseWriter, r *http.Request, repoId int64, params GetContentReposRepoIdSystemsParams) {
+	var request GetContentReposRepoIdSystemsRequestObject
+
+	request.RepoId = repoId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetContentReposRepoIdSystems(ctx, request.(GetContentReposRepoIdSystemsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetContentReposRepoIdSystems")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetContentReposRepoIdSystemsResponseObject); ok {
+		if err := validResponse.VisitGetContentReposRepoIdSystemsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetContentSystems operation middleware
+func (sh *strictHandler) GetContentSystems(w http.ResponseWriter, r *http.Request, params GetContentSystemsParams) {
+	var request GetContentSystemsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetContentSystems(ctx, request.(GetContentSystemsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetContentSystems")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetContentSystemsResponseObject); ok {
+		if err := validResponse.VisitGetContentSystemsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSystemsInventoryIdPackages operation middleware
+func (sh *strictHandler) GetSystemsInventoryIdPackages(w http.ResponseWriter, r *http.Request, inventoryId string) {
+	var request GetSystemsInventoryIdPackagesRequestObject
+
+	request.InventoryId = inventoryId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSystemsInventoryIdPackages(ctx, request.(GetSystemsInventoryIdPackagesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSystemsInventoryIdPackages")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSystemsInventoryIdPackagesResponseObject); ok {
+		if err := validResponse.VisitGetSystemsInventoryIdPackagesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/5RT32vbTgz/V4SeWjBpv9+95W3r2OhgW6B9G6PIPsU+er67SXKCKf7fx9lpSrum0Kfk",
-	"dNLnl08P6OM24foBHWsjPptPEdd4y2rA0eXkoylsk8COgndkPrbw7ef1D9CGI4lPCjXbnjkCNQ2rgnKZ",
-	"kNTDTfYNf/4EFB1sfTAWdhVoEmM3FzO17JYBH8HHHUdLMq6wQvMWGNe4SVfQpKgpcPk1jvbUCB8311jh",
-	"jkUX3f+tLleXOFWYMkfKHtf4YXW5+h8rzGSdFqcXB5iLTM09tTwXW7Z/U/jKBo9NQDvygerAcxqZrOnm",
-	"k45q3GvRnDILldlrt0xfLUybR6KiQqhnY1Fc/3rJt6GWIQ59zXIgWeYKti8NfwaWESuM1JdsSnxYoTYd",
-	"91Tk25hL3UfjlgWnqXpJ8b3oLm5CeLK275IyFNA5YvJRwTqvcFQLpECgQ60mPrYn9PQL+GuSDnOvKPoy",
-	"P4xnfufDvvNNB9axMJAcc4a9t26J/9lHOTMZ+BySAAVhciMMGSyBI2M421JQPj+d44x2d0R7zUGdUmCK",
-	"b1g4yId6BKNTGS0378jntuMj8j2PxVPZIKjHU3bK9d09j+/juUkyLzeVxXbl31OkpfOx+HaUM3cSx/J2",
-	"hr8rFNacopb9i0MIU4U69D3JiGs8LM7xReA0TX8DAAD///VW2n6sBAAA",
+	"H4sIAAAAAAAC/+xd/2/cuHL/Vwi1QB1UWTuJ21cY6A95ucs7v94lbpxrgQbBgivN7vJZIhWSsrMX+H8v",
+	"hl8kSkuttLbPF+P2hzvvrvhlOBzOlw9nlG9JJspKcOBaJWffEgmqElyB+fJXmn+ALzUojd8ywTVw85FW",
+	"VcEyqpngx5UUiwLKf/2HEhyfqWwNJcVP/yxhmZwl/3TcTnFsn6rjC9srub29TZMcVCZZhcMlZ8k5v6YF",
+	"y4m0U5OKSlqCBqlmyW2avBVywfIc+GPS9HENhOXANdMbwhThQhNaFOIGcqIFoVkGShG9Bvwoaq6JkOYr",
+	"01Aass+5BslpcQnyGuSPUgr52AvIqaYLqoB8qUFuyJKyAnJD3Duh34qa54/OUg0lyQVYhsJXpgzjPIMN",
+	"V9miAEMkco5l8Cun15QVdFHAY5J7WbEMfvgryURd5Ia8BRAJNFs7Hv7Kaa3XQrLf4NH56I9KRqVkhpvE",
+	"niEvs7MEO7rRcLLX+TVTAhtf0E0hqCG5O/AFXQERS0KbprMkTSopKpCaWQ2BIoV/cSPV2CLcnJtzjStJ",
+	"E72pIDlLqJR0g98Lxq9GB/mZKf2zaXibJiVoOqXDL9gOOYCMYhI36JOl3Y3hJ//cUCUW/4BM4ySe7B9A",
+	"U1bgdF0eOP5s5rbnNz+C0pLxFY6QXcO8YEp3OLXVqs+Ozm5E2jOzZ0shS6qTs4Rx/e+nSTMM4xpWIA2X",
+	"RM6WDPJ5TjV0+uAPzzUrIUm3x+e0jC+notkVXcHcb35fbMxTRSQUQBXkZLGxmtHxKSVUEaWFhDwlvC4K",
+	"crMG3mlC1hSFmANKHDaxp13LGnD+elGwbM/VSFgIoeetADQLWwhRAOXYSME1SKY3+LSkX1lZl8nZaZqU",
+	"jNvPL/rkxPitRFH7XRto3dKl6rKkchNltNpwUSmmBh4qFKW5Uy5OHUaoce0YV5oWxXDDWhYTKO4dIpYn",
+	"TlICcttVdaU47Z2V7lb2BTU4N9vbF19XlCu7zrRRRXc40ZPP3vAhmiDE48LzMPK6v5j1bKNt0z3CpgMo",
+	"okVKGM+KOmd8ZZoEO0YEt2blznI7TR77cvcgwmOXHRehnKmqoJv54P4vmVQoz5WQGvLpeozxa+Aal9KT",
+	"wbpmeayD0lTXA3trHrmBxhgbzpt21xeO5D8nW0scZ+W4L+J2iNDlEjJtbQvljdA9kIMSbOzTcVPaGbeE",
+	"0WxEVAI4fNXb3P6FKYWHVVirXFATha1gFpOvSsI1E7UaHcZQMTROb9GW4qFl/uL42dPaJlywhxfyOY0s",
+	"7H8bR8MFbEYHuR7uxzmuRzHBUyJ4sSFefr0bU1INktEC/XwCXyuQrAQM+OzIzjkiaLdwndNOdW/qiIp1",
+	"4YdvQY7+D/KP4gr4MzwXW2sy+4bh1A1VRALNyQ3T64dZUkSXFRqktZw5Q4ppcdHZm60u3dW9NQMowjgB",
+	"c7JnSWTrC1YyHfddKglzS8UczxaVzIVXu4MqsBO/abvcpomJjuei0qxkv1Ed3Y6/C8aJ0pJqWG2IgqLR",
+	"Rsiw/8YRnr8PRiBroDnIAf5XEp5b6u/CfSVkRNQvhdTkCjYpjr5kX8FKAHluvW1sDBxNcnRMLTQt5o3S",
+	"HHV0esc37O73zRHaSEvscLvYwZvU7opeN6xwAt/hDME4+Ao2ilAJ4eO5Q2RMuEIyUdQlN5KmOL2CeUYV",
+	"pKSBvcgClqI7AEEbp8gNSPRucshJjVENtvibIEsGRe6avM6y8x9ScnG1ekdLwI+XG3VuXQzIw29W7/Ac",
+	"f3rdeBsR89WNAUcdwoJqUHoO1zISmb2DG1Ca1BWqItKgKGQpZGe9ghPKN57L3uQaJiLWMcEvHQ0dscF8",
+	"shMt180mxn2VThg1St2DR069hjDFn+quaZszgRfbH/ye7qs7ZPfyXhtiGlG7v6N6L9ltCPJSHEILTCOk",
+	"WFcI1WL/STJsp5q37jNwjKg+Je8EhwQB3ZD3r2M8H4qZd7rSPdb2CRnd0elOtLEHtDn2zcT39KK35evp",
+	"ONGO9v8BiU5W/HAMSrzj617KJB5IO9N/bckgN2uWrcmaXqPN6ITP7jx0DeJs3EA70fIUx8kbZ9C4rLXn",
+	"0i1G4a80JPX+ohZu15OTtXEeOl6ph2HWU+NSxEnf4lT7LOYbmpjTajv7qHUh10LpwP0e8s+pztbP4156",
+	"g11bGuD5hYTnlmLn9KPtQRuzvX3W/JdMlThBxONrwXTByULotSFekZu1UN4zs46ZIjlbLkHGsTT4qiVt",
+	"3fmhOZYtf0oXuy+lKPsxSjWgY6wJ59lmHpvmZ/vMz9Ky8F8U8dFtIcRVXRnH2ERhwSy8Lhd2Ekfa5OV0",
+	"CY+sbHA5QVS5x8p6MjVtUcFU94y9AkqHVjA4XZ+5XclJtwU2fmLtXeUWnz68fUP+8h8nfyHuDpTkIqtR",
+	"AmJxj79r6w7x49eqoNyG1IbdTBGRZbWUwLPWDtrxZ7vh0O7IP338eEHsQ5KJfEAiNNNRu73GaNvFIJ6K",
+	"K8Zz/LyLGn/B0B3u1w/nRMIS7KLsHe5y4+Hz3rhnxN8pq2Nz6Wu4kwa/Kjxd+SL8qUkFEJLQhaj12aKg",
+	"/GocmDNPPR8absbE4ANUYsCB4qZ7HsXn9BqsRy/kinIPnzBFfC904vG5u1/33EadrZgWnfMVXO3d/8Zm",
+	"p3un10zm84pKvYldLe64pQh7hh5Zw6Uh5t7R3W8ZRYDTxf39/Sfp6CMDx1nX8Or+yQ/NcXg6PNqFEGS1",
+	"CVE1K0FpWlZ3v8OcijXgIPPmHm9Rr+bGGsWPY7TPvu2Br/fuoyDb3WdvUETpeV15Mb0bi30AsRugCtqZ",
+	"sHIH3kU1FAXTMC8pp6vBbApNC9jx6CGkxw50QyVHr+UBBtxTke+EciJ8jzJ5p7yOCufoCRkVVb9VW8Zo",
+	"a5+H1UQPuo8nVERAfffEYRxNzlAU05uE2w3CM8OGfQ/s+AFRQT+Y2tPSOk7/ajpvm5OehDpRjGKJLQWj",
+	"++pm27mz07diMCdhAKby5DazjdK7y7bbBoF44YWHc5MexBcaQVr2NAIjKgfJG2bHZPfwz+cE3pqtWIpt",
+	"1nwEpQnwvBKMa2XuGHxkxVfk7+/P3xGVAaeSCbww1DcA3F+/K9DKYgse1MDA38baeAeoLLKEPyLwkNsO",
+	"jJNmX2dNfHWWXIg3GOgoUbQBT9OQvL44T9LEAavJWfJidjI7Qe6JCjitWHKWvJqdzF4aq6PXZgeO3TDH",
+	"bVov/ryCyB3y30AH2b+kvVfyMVibod2NMmx8ZmCpIADRa7BpYRuiBa4Spc3Ed+e5ne2Npa1NTjaU+/T/",
+	"5OxTVIgtimK2qZurzLCJwVx8uHWWINOTNEi73oZTtvJYbGadn6eTEk0qjysdvXj+4uQkJTksaV1o8vLk",
+	"2RANzYX4XkQYxIXQogint0ggjmvkgzKuLCrSsI1QhdqtXjh9EifJAzoRolo99C2attFjvMX3sW9KYLaa",
+	"EQVZLZnepGRRr5bsK2IOwNeUZ+DBnxhFWyl7D0KXz5hMyQty9LO4eYaifEqO3kimWUaLwT3zHffctjgp",
+	"JvlTrVEPmIIHutQG9WCKaFbCoOy2OaNzVDEdWqYkGt2VviYpYg8CtXgA8j6GGaVXgIrDaFCy2JxZqT9y",
+	"5+1ZSny+Z0o6spMGmx4QmJLInRcudvsafVAmhNTzK9jsJ52YmYN2hPoMHHKkZQ3PcO42LYccLWmh4NnO",
+	"uYXMQcZmb+OVz2m3puvlycmO6pT9qlK2i0gi9Snv/wst0unJydBoDXnHQb2Z6fJivEun6sZ0ejXeqS0h",
+	"u02Tf5tCWax6y/SdMFukbun2Ngw7Emf2ghOIg0cs9fG3RrJRDG53Wm40whZHt7qP93KzG0uuRAndJMLQ",
+	"ok8z1O7T5p2PfHcY7Xc0mK8pCjG24sNPl6+fvzx5+ersxctXp43so/cSsQwutmldPxu0DZ/FRzgMvkzo",
+	"CZ6E05PT8R5NqeD3cXQa/CB3bJ90bo4DLH/n+Yk4uDtSz/c7JpcN1P+dnpZ0zNsONMTv5mp7tj+Wnx04",
+	"RCpW1sJUJwHn6MTYbZM2EGjVoxfPiOCDtjusltiDto9NOuaWLxQijx2fyE+FVHYLMp6yV5PGM6fwTnTJ",
+	"MC3QJZRT5T5+snybuwafP5m83c+z2eyTPbVCfv7Pa1rUkBLAv9RE6SsMqIKQe4aX+2rWHczmHi8kza5A",
+	"K7Ji1z5/WK8J4y5Wdo1TIiqbIV9syFK4GvLFhgjeHHNPkSLwJdxMDl9SstL4H6Sk0PgfYHUVOcpEWVKi",
+	"AFUJUm6Wop6lbVR4ZNAYAoWJunA0VuDmcKHnnBWzcGADTxQarFyb2NAmOiuyFoXZSOrO6IxcwjVIWjQ8",
+	"L2tliuNtv5lJIqgKkTegamzjbefOpnfRqC7Lh2sNOrhtA/Vs/ZImSm9wnCQHqN67Xx/DP+jhcgc/4VH8",
+	"hMvd1rvjN/gbmp3egW/Uy0U2qWJ0ovvsoeh9Ua4wIfB3M7zNAh/L8r6v6JcaSFZLJSTR9Ap4m6SFhWqo",
+	"rXy1GUFU16WS+p+8PMzIG8rdGxoyUS4Y98UvPtUrRrKddz+jF4JyDb++F0iuIQi/2Os1vQYJpkCmA9dW",
+	"fhmNKLe2mxYSaL4JsufHbLgbbd6Mtqcpf+uT9Rz5mIRJV6pfj2LRZAmdexxjDLEVwk1mD1RFMzi+go21",
+	"7jPyASqg2hlovyFaEOcXW7CIFmbOicZL01VnjdPf8eCNEJKbxB0+x4eex3dw3g7O28F5ezTnrX+rfMA5",
+	"74NzNg5WzOs6/haWwt0LqtFrqm3ZTFgS0KlzGnPN3N874jVB3koEmQnXeQBm7gDMDDgv/XrWP9KZ2Ruy",
+	"QTK7JYAHU38w9QdT/9im/unDNN8R6jJUaTzJAfCFo6MeQFhhGjX4vq52V9rStoETnADN1n54c+bW4oaU",
+	"7UsSyh3FuXt6Gb6y9im4GZ7hv6uf0ezqYzkaH1tR2rLZaI+7KSdORmQsm+SQNzKlbPugY++lY893V9h3",
+	"VKypZxrVo2HVk68RG1ad6S7dibryZnpRX7lLWZqSrX2h8n4F1++mpjpM+yOSQjsEfC8YtKknIaaeJNBW",
+	"HyAnP1HtVdWkTepVpuwZggVFj2NZhMFEZDyU/rOr8k4d5UGB3wcVC0/BttY+/oZ/5iy/FyLWqOp4FfBO",
+	"1Yv/O88nomDnebwwO+KfunXtdE0nvAThz4iJDaJL6fYb3Dww4FVdB396dgCZDiDTAWR6NJAp8v6GQyLQ",
+	"HwFJbdvBjul9AFPLmlQGc5CaRFJbH7fL6k40tX8GQxcGOg2DTYzjrNh3EOuYGvrA0C0lqHUT44zFEa4A",
+	"/w6JMls3Yi0J+FXUbaAVhmNOxB8+3oqQ5V4fEFDGhW5/ddQtNuTSv3FgkE9bryR4oPu4g8d08JgOHtP3",
+	"6zEd7uMeEGrwbg26Ou2LGK2gNI1/DB5gQ9fp+Fv4BorbadnSKM/BTdXAyzdCn6lZg7tGUzPy2r5hwSkE",
+	"14Upkq0hu/IpvpTg+wsLaF6/8AafXoA0bzbEDNFmtrbk0fyLffi5ViBJZrOHzb+agNpIkdOTV9Z5a7rW",
+	"WrEc+kOcnpxGHTonvOeecef51NTvpgthedc6DaApvdeDfB+1kfFXsxwinkc59OELUb0AY5v/HwB+7Wks",
+	"+3MAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
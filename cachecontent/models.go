@@ -249,11 +249,11 @@ func (TimestampKV) TableName() string {
 }
 
 type PackageAccountData struct {
-	AccID          int   `gorm:"column:rh_account_id;primaryKey"`
-	PkgNameID      int64 `gorm:"column:package_name_id;primaryKey"`
-	SysInstalled   int   `gorm:"column:systems_installed"`
-	SysInstallable int   `gorm:"column:systems_installable"`
-	SysApplicable  int   `gorm:"column:systems_applicable"`
+	AccID          int   `gorm:"column:rh_account_id;primaryKey" json:"rh_account_id"`
+	PkgNameID      int64 `gorm:"column:package_name_id;primaryKey" json:"package_name_id"`
+	SysInstalled   int   `gorm:"column:systems_installed" json:"systems_installed"`
+	SysInstallable int   `gorm:"column:systems_installable" json:"systems_installable"`
+	SysApplicable  int   `gorm:"column:systems_applicable" json:"systems_applicable"`
 }
 
 func (PackageAccountData) TableName() string {
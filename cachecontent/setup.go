@@ -16,6 +16,7 @@ var (
 	DbReadReplica      *gorm.DB //nolint:stylecheck
 	OtherAdvisoryTypes []string
 	AdvisoryTypes      map[int]string
+	AdvisorySeverities map[int]string
 	globalPgConfig     *PostgreSQLConfig
 )
 
@@ -200,4 +201,20 @@ func loadAdditionalParamsFromDB() {
 	for _, at := range types {
 		AdvisoryTypes[at.ID] = at.Name
 	}
+
+	// Load AdvisorySeverities
+	var severities []AdvisorySeverity
+
+	err = Db.Table("advisory_severity").
+		Select("id, name").
+		Scan(&severities).Error
+	fmt.Println("advisory_severities", severities, "Advisory severities loaded from DB")
+	if err != nil {
+		panic(err)
+	}
+
+	AdvisorySeverities = make(map[int]string)
+	for _, as := range severities {
+		AdvisorySeverities[as.ID] = as.Name
+	}
 }
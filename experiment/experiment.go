@@ -0,0 +1,212 @@
+package experiment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/authzed-go/v1"
+	"github.com/jackc/pgx/v5"
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/problem"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Header selects the experiment serving a request
+const Header = "Experiment"
+
+// Default serves requests without an Experiment header
+const Default = "pre-filter"
+
+// Dependency is a service an experiment needs to be set up
+type Dependency string
+
+const (
+	SpiceDB Dependency = "spicedb"
+	// the experimental spicedb apis, e.g. BulkCheckPermission
+	SpiceDBExperimental Dependency = "spicedb-experimental"
+	Postgres            Dependency = "postgres"
+)
+
+// Dependencies are the clients shared by the experiments
+type Dependencies struct {
+	Tracer                    trace.Tracer
+	SpicedbClient             *authzed.Client
+	SpicedbExperimentalClient v1.ExperimentalServiceClient
+	PostgresConn              *pgx.Conn
+}
+
+func (d Dependencies) has(dep Dependency) bool {
+	switch dep {
+	case SpiceDB:
+		return d.SpicedbClient != nil
+	case SpiceDBExperimental:
+		return d.SpicedbExperimentalClient != nil
+	case Postgres:
+		return d.PostgresConn != nil
+	}
+	return false
+}
+
+// Experiment is one way of answering the content api which is compared with the others.
+// Experiments register themselves with Register from an init function.
+type Experiment interface {
+	// Name is the value of the Experiment header selecting the experiment
+	Name() string
+	Description() string
+	// Requires lists the dependencies which have to be available for the experiment to be set up
+	Requires() []Dependency
+	// Setup prepares the experiment before the server starts, Teardown releases what Setup acquired
+	Setup(ctx context.Context, deps Dependencies) error
+	Teardown(ctx context.Context) error
+	// Server answers the requests of the experiment, it is called after Setup
+	Server() api.StrictServerInterface
+}
+
+var (
+	registered = map[string]Experiment{}
+	// experiments which were set up, in the order of their names
+	active []Experiment
+)
+
+func Register(e Experiment) {
+	if _, found := registered[e.Name()]; found {
+		panic(fmt.Sprintf("experiment %s registered twice", e.Name()))
+	}
+	registered[e.Name()] = e
+}
+
+func names(experiments map[string]Experiment) []string {
+	names := make([]string, 0, len(experiments))
+	for name := range experiments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Setup sets up the registered experiments. Experiments missing a dependency are left out, an experiment failing its
+// setup fails them all.
+func Setup(ctx context.Context, deps Dependencies) error {
+	for _, name := range names(registered) {
+		e := registered[name]
+
+		missing := false
+		for _, dep := range e.Requires() {
+			if !deps.has(dep) {
+				fmt.Printf("experiment %s is disabled, %s is not available\n", name, dep)
+				missing = true
+			}
+		}
+		if missing {
+			continue
+		}
+
+		if err := e.Setup(ctx, deps); err != nil {
+			return fmt.Errorf("error setting up experiment %s: %v", name, err)
+		}
+		active = append(active, e)
+	}
+	return nil
+}
+
+// Teardown tears down the experiments which were set up, in reverse order
+func Teardown(ctx context.Context) error {
+	var errs []string
+	for i := len(active) - 1; i >= 0; i-- {
+		if err := active[i].Teardown(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", active[i].Name(), err))
+		}
+	}
+	active = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error tearing down experiments: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ExperimentItem describes an experiment in the GET /experiments response
+type ExperimentItem struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Requires    []Dependency `json:"requires"`
+	Default     bool         `json:"default"`
+}
+
+type ExperimentsPayload struct {
+	Data []ExperimentItem `json:"data"`
+}
+
+func listExperiments(w http.ResponseWriter) error {
+	payload := ExperimentsPayload{Data: make([]ExperimentItem, 0, len(active))}
+	for _, e := range active {
+		requires := e.Requires()
+		if requires == nil {
+			requires = []Dependency{}
+		}
+		payload.Data = append(payload.Data, ExperimentItem{
+			Name:        e.Name(),
+			Description: e.Description(),
+			Requires:    requires,
+			Default:     e.Name() == Default,
+		})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(body)
+	return err
+}
+
+// ListHandler answers GET /experiments with the experiments available. The list only describes the server, it
+// needs no identity.
+func ListHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			problem.Write(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s is not supported", r.Method))
+			return
+		}
+
+		if err := listExperiments(w); err != nil {
+			fmt.Println(err)
+		}
+	})
+}
+
+// Handler routes the requests to the experiment selected by the Experiment header. newHandler serves the strict
+// server of an experiment.
+func Handler(newHandler func(api.StrictServerInterface) http.Handler) http.Handler {
+	handlers := map[string]http.Handler{}
+	for _, e := range active {
+		handlers[e.Name()] = newHandler(e.Server())
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.Header.Get(Header)
+		if name == "" {
+			name = Default
+		}
+
+		h, found := handlers[name]
+		if !found {
+			available := make([]string, 0, len(active))
+			for _, e := range active {
+				available = append(available, e.Name())
+			}
+			detail := fmt.Sprintf("unknown %s %s, must be one of %s", Header, name, strings.Join(available, ", "))
+			problem.New(http.StatusBadRequest, detail).WithType(problem.TypeValidation).Write(w)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
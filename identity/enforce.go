@@ -0,0 +1,228 @@
+package identity
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/authzed-go/v1"
+	"github.com/merlante/inventory-access-poc/problem"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// how long the organizations of a user are trusted before they are walked again
+	membershipTTL = time.Minute
+	// users whose organizations are kept, the entry closest to expiry makes room for a new one
+	maxMemberships = 10000
+)
+
+type membership struct {
+	orgs    map[string]bool
+	expires time.Time
+}
+
+// Enforcer rejects requests without an identity and requests claiming an account which is not an
+// organization the user belongs to in SpiceDB
+type Enforcer struct {
+	Tracer        trace.Tracer
+	SpicedbClient *authzed.Client
+
+	mu          sync.Mutex
+	memberships map[string]membership
+}
+
+func (en *Enforcer) Middleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, found := FromContext(r.Context())
+		if !found {
+			problem.Write(w, http.StatusUnauthorized, "no identity in request")
+			return
+		}
+		if id.RhAccountID == 0 {
+			problem.Write(w, http.StatusForbidden, fmt.Sprintf("organization %s has no account", id.OrgID))
+			return
+		}
+
+		orgs, err := en.organizations(r.Context(), id.UserID)
+		if err != nil {
+			fmt.Println(err)
+			problem.New(http.StatusServiceUnavailable, "failed to look up the organizations of the user").WithType(problem.TypeSpiceDB).Write(w)
+			return
+		}
+		if !orgs[strconv.FormatInt(id.RhAccountID, 10)] {
+			problem.Write(w, http.StatusForbidden, fmt.Sprintf("user %s does not belong to organization %s", id.UserID, id.OrgID))
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// organizations gives the ids of the organizations the user is granted a role in, either directly or on one of
+// their workspaces. Roles granted to a group count for its members.
+func (en *Enforcer) organizations(ctx context.Context, user string) (map[string]bool, error) {
+	en.mu.Lock()
+	m, found := en.memberships[user]
+	en.mu.Unlock()
+	if found && time.Now().Before(m.expires) {
+		return m.orgs, nil
+	}
+
+	ctx, span := en.Tracer.Start(ctx, "SpiceDB organization membership")
+	defer span.End()
+
+	bindings, err := en.lookupBindings(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := map[string]bool{}
+	var workspaces []string
+	for _, binding := range bindings {
+		for _, resourceType := range []string{"organization", "workspace"} {
+			granted, err := en.readRelated(ctx, &v1.RelationshipFilter{
+				ResourceType:     resourceType,
+				OptionalRelation: "user_grant",
+				OptionalSubjectFilter: &v1.SubjectFilter{
+					SubjectType:       "role_binding",
+					OptionalSubjectId: binding,
+				},
+			}, true)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, ref := range granted {
+				if resourceType == "organization" {
+					orgs[ref.GetObjectId()] = true
+				} else {
+					workspaces = append(workspaces, ref.GetObjectId())
+				}
+			}
+		}
+	}
+
+	// walk up the parent chain of the workspaces to their organization
+	visited := map[string]bool{}
+	for len(workspaces) > 0 {
+		workspace := workspaces[len(workspaces)-1]
+		workspaces = workspaces[:len(workspaces)-1]
+		if visited[workspace] {
+			continue
+		}
+		visited[workspace] = true
+
+		parents, err := en.readRelated(ctx, &v1.RelationshipFilter{
+			ResourceType:       "workspace",
+			OptionalResourceId: workspace,
+			OptionalRelation:   "parent",
+		}, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, parent := range parents {
+			if parent.GetObjectType() == "organization" {
+				orgs[parent.GetObjectId()] = true
+			} else {
+				workspaces = append(workspaces, parent.GetObjectId())
+			}
+		}
+	}
+
+	en.remember(user, orgs)
+
+	return orgs, nil
+}
+
+// remember caches the organizations of the user, making room by dropping the expired entries or else the one
+// closest to expiry
+func (en *Enforcer) remember(user string, orgs map[string]bool) {
+	en.mu.Lock()
+	defer en.mu.Unlock()
+
+	if en.memberships == nil {
+		en.memberships = map[string]membership{}
+	}
+
+	if _, found := en.memberships[user]; !found && len(en.memberships) >= maxMemberships {
+		now := time.Now()
+		oldest := ""
+		for u, m := range en.memberships {
+			if now.After(m.expires) {
+				delete(en.memberships, u)
+			} else if oldest == "" || m.expires.Before(en.memberships[oldest].expires) {
+				oldest = u
+			}
+		}
+		if len(en.memberships) >= maxMemberships {
+			delete(en.memberships, oldest)
+		}
+	}
+
+	en.memberships[user] = membership{orgs: orgs, expires: time.Now().Add(membershipTTL)}
+}
+
+// lookupBindings gives the ids of the role bindings the user is a subject of, directly or as a member of a group
+func (en *Enforcer) lookupBindings(ctx context.Context, user string) ([]string, error) {
+	lrClient, err := en.SpicedbClient.LookupResources(ctx, &v1.LookupResourcesRequest{
+		ResourceObjectType: "role_binding",
+		Permission:         "subject",
+		Subject: &v1.SubjectReference{
+			Object: &v1.ObjectReference{
+				ObjectType: "user",
+				ObjectId:   user,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("spicedb error: %v", err)
+	}
+
+	var bindings []string
+	for {
+		next, err := lrClient.Recv()
+		if e.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("spicedb error: %v", err)
+		}
+
+		bindings = append(bindings, next.GetResourceObjectId())
+	}
+	return bindings, nil
+}
+
+// readRelated reads the relationships of the filter and gives their resources, or their subjects when resources is false
+func (en *Enforcer) readRelated(ctx context.Context, filter *v1.RelationshipFilter, resources bool) ([]*v1.ObjectReference, error) {
+	rrClient, err := en.SpicedbClient.ReadRelationships(ctx, &v1.ReadRelationshipsRequest{RelationshipFilter: filter})
+	if err != nil {
+		return nil, fmt.Errorf("spicedb error: %v", err)
+	}
+
+	var refs []*v1.ObjectReference
+	for {
+		next, err := rrClient.Recv()
+		if e.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("spicedb error: %v", err)
+		}
+
+		if resources {
+			refs = append(refs, next.GetRelationship().GetResource())
+		} else {
+			refs = append(refs, next.GetRelationship().GetSubject().GetObject())
+		}
+	}
+	return refs, nil
+}
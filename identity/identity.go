@@ -0,0 +1,170 @@
+package identity
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"github.com/merlante/inventory-access-poc/problem"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// Header is the request header set by the console gateway, a base64 encoded XRHID
+const Header = "x-rh-identity"
+
+const (
+	TypeUser           = "User"
+	TypeServiceAccount = "ServiceAccount"
+)
+
+type contextKey struct{}
+
+// XRHID is the JSON document carried in the x-rh-identity header
+type XRHID struct {
+	Identity struct {
+		AccountNumber string `json:"account_number"`
+		OrgID         string `json:"org_id"`
+		Type          string `json:"type"`
+		AuthType      string `json:"auth_type"`
+		Internal      struct {
+			OrgID string `json:"org_id"`
+		} `json:"internal"`
+		User *struct {
+			UserID   string `json:"user_id"`
+			Username string `json:"username"`
+		} `json:"user"`
+		ServiceAccount *struct {
+			ClientID string `json:"client_id"`
+			Username string `json:"username"`
+		} `json:"service_account"`
+	} `json:"identity"`
+}
+
+// Identity is the caller of a request
+type Identity struct {
+	// User or ServiceAccount
+	Type string
+	// the spicedb user id, user_id of a user and client_id of a service account
+	UserID        string
+	Username      string
+	OrgID         string
+	AccountNumber string
+	// rh_account.id of the organization
+	RhAccountID int64
+}
+
+// Parse decodes an x-rh-identity header value. The rh_account id is not known from the header and is left 0.
+func Parse(header string) (Identity, error) {
+	raw, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return Identity{}, errors.Wrap(err, "invalid identity header encoding")
+	}
+
+	var xrhid XRHID
+	if err := json.Unmarshal(raw, &xrhid); err != nil {
+		return Identity{}, errors.Wrap(err, "invalid identity header")
+	}
+
+	id := Identity{
+		Type:          xrhid.Identity.Type,
+		OrgID:         xrhid.Identity.OrgID,
+		AccountNumber: xrhid.Identity.AccountNumber,
+	}
+	if id.OrgID == "" {
+		id.OrgID = xrhid.Identity.Internal.OrgID
+	}
+	if id.OrgID == "" {
+		return Identity{}, errors.New("identity header has no org_id")
+	}
+
+	switch id.Type {
+	case TypeUser:
+		if u := xrhid.Identity.User; u != nil {
+			id.UserID = u.UserID
+			id.Username = u.Username
+		}
+	case TypeServiceAccount:
+		if sa := xrhid.Identity.ServiceAccount; sa != nil {
+			id.UserID = sa.ClientID
+			id.Username = sa.Username
+		}
+	default:
+		return Identity{}, errors.Errorf("unsupported identity type %q", id.Type)
+	}
+	if id.UserID == "" {
+		return Identity{}, errors.Errorf("identity header has no id for %s", id.Type)
+	}
+
+	return id, nil
+}
+
+func NewContext(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, found := ctx.Value(contextKey{}).(Identity)
+	return id, found
+}
+
+// org_id -> rh_account.id, accounts are never renumbered so the mapping is kept for the life of the process
+var rhAccountIDs sync.Map
+
+// RhAccountID maps an org_id to the rh_account id, 0 when there is no such account
+func RhAccountID(orgID string) (int64, error) {
+	if id, found := rhAccountIDs.Load(orgID); found {
+		return id.(int64), nil
+	}
+
+	var account cachecontent.RhAccount
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		return tx.Where("org_id = ?", orgID).Limit(1).Find(&account).Error
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get rh_account")
+	}
+	if account.ID == 0 {
+		return 0, nil
+	}
+
+	rhAccountIDs.Store(orgID, int64(account.ID))
+	return int64(account.ID), nil
+}
+
+// Middleware puts the identity of the x-rh-identity header into the request context.
+// Requests without the header are passed on without one, a header which cannot be parsed is unauthorized.
+func Middleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(Header)
+		if header == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		id, err := Parse(header)
+		if err != nil {
+			problem.Write(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		serveWithIdentity(w, r, h, id)
+	})
+}
+
+// serveWithIdentity completes the identity with its rh_account id and passes the request on with it
+func serveWithIdentity(w http.ResponseWriter, r *http.Request, h http.Handler, id Identity) {
+	var err error
+	id.RhAccountID, err = RhAccountID(id.OrgID)
+	if err != nil {
+		fmt.Println(err)
+		problem.New(http.StatusInternalServerError, "failed to resolve the organization").WithType(problem.TypeDatabase).Write(w)
+		return
+	}
+
+	h.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+}
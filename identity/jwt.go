@@ -0,0 +1,325 @@
+package identity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/merlante/inventory-access-poc/problem"
+	"github.com/pkg/errors"
+)
+
+// JWTConfig configures bearer token authentication
+type JWTConfig struct {
+	// path of a JWKS file, or an http(s) URL to fetch it from at startup
+	JWKS     string
+	Issuer   string
+	Audience string
+	// claim holding the spicedb user id, sub by default
+	UserClaim string
+	// claim holding the org_id of the account, org_id by default
+	AccountClaim string
+	// allowed clock skew for exp and nbf
+	Leeway time.Duration
+}
+
+// JWTAuthenticator validates RS256 and ES256 bearer tokens against the keys of a JWKS
+type JWTAuthenticator struct {
+	config JWTConfig
+	keys   map[string]crypto.PublicKey
+	now    func() time.Time
+}
+
+// RSA keys shorter than this are refused, they no longer give the security RS256 is assumed to have
+const minRSAKeyBits = 2048
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func NewJWTAuthenticator(config JWTConfig) (*JWTAuthenticator, error) {
+	if config.UserClaim == "" {
+		config.UserClaim = "sub"
+	}
+	if config.AccountClaim == "" {
+		config.AccountClaim = "org_id"
+	}
+	if config.Issuer == "" || config.Audience == "" {
+		return nil, errors.New("jwt issuer and audience must be configured")
+	}
+
+	raw, err := readJWKS(config.JWKS)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseJWKS(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWTAuthenticator{config: config, keys: keys, now: time.Now}, nil
+}
+
+func readJWKS(location string) ([]byte, error) {
+	if location == "" {
+		return nil, errors.New("no jwks configured")
+	}
+
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		raw, err := os.ReadFile(location)
+		return raw, errors.Wrap(err, "failed to read jwks")
+	}
+
+	client := http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(location)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch jwks")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to fetch jwks: %s", resp.Status)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	return raw, errors.Wrap(err, "failed to fetch jwks")
+}
+
+// parseJWKS keeps the RSA and P-256 keys of the set, keys meant for encryption are skipped and short RSA keys
+// are refused
+func parseJWKS(raw []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, errors.Wrap(err, "invalid jwks")
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		switch k.Kty {
+		case "RSA":
+			n, err := decodeBigInt(k.N)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid jwk %s", k.Kid)
+			}
+			if n.BitLen() < minRSAKeyBits {
+				return nil, errors.Errorf("invalid jwk %s, RSA keys must have at least %d bits", k.Kid, minRSAKeyBits)
+			}
+			e, err := decodeBigInt(k.E)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid jwk %s", k.Kid)
+			}
+			if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+				return nil, errors.Errorf("invalid jwk %s, bad RSA exponent", k.Kid)
+			}
+			keys[k.Kid] = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		case "EC":
+			if k.Crv != "P-256" {
+				continue
+			}
+			x, err := decodeBigInt(k.X)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid jwk %s", k.Kid)
+			}
+			y, err := decodeBigInt(k.Y)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid jwk %s", k.Kid)
+			}
+			if !elliptic.P256().IsOnCurve(x, y) {
+				return nil, errors.Errorf("invalid jwk %s, point is not on the curve", k.Kid)
+			}
+			keys[k.Kid] = &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("jwks has no usable signing keys")
+	}
+	return keys, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// Authenticate verifies the token and gives the identity of its configured claims.
+// The rh_account id is left 0, like for Parse.
+func (a *JWTAuthenticator) Authenticate(token string) (Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Identity{}, errors.New("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return Identity{}, errors.Wrap(err, "malformed token header")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Identity{}, errors.Wrap(err, "malformed token signature")
+	}
+	if err := a.verify(header, parts[0]+"."+parts[1], signature); err != nil {
+		return Identity{}, err
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Identity{}, errors.Wrap(err, "malformed token claims")
+	}
+	if err := a.validateClaims(claims); err != nil {
+		return Identity{}, err
+	}
+
+	id := Identity{Type: TypeUser}
+	if id.UserID = claimString(claims, a.config.UserClaim); id.UserID == "" {
+		return Identity{}, errors.Errorf("token has no %s claim", a.config.UserClaim)
+	}
+	if id.OrgID = claimString(claims, a.config.AccountClaim); id.OrgID == "" {
+		return Identity{}, errors.Errorf("token has no %s claim", a.config.AccountClaim)
+	}
+	return id, nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func (a *JWTAuthenticator) verify(header jwtHeader, signed string, signature []byte) error {
+	key, found := a.keys[header.Kid]
+	if !found {
+		return errors.Errorf("unknown signing key %q", header.Kid)
+	}
+	digest := sha256.Sum256([]byte(signed))
+
+	switch header.Alg {
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.Errorf("key %q is not an RSA key", header.Kid)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("invalid token signature")
+		}
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.Errorf("key %q is not an EC key", header.Kid)
+		}
+		// JWS signatures are r and s as fixed width big endian integers, not ASN.1
+		if len(signature) != 64 {
+			return errors.New("invalid token signature")
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			return errors.New("invalid token signature")
+		}
+	default:
+		return errors.Errorf("unsupported token algorithm %q", header.Alg)
+	}
+	return nil
+}
+
+func (a *JWTAuthenticator) validateClaims(claims map[string]interface{}) error {
+	now := a.now()
+
+	if iss, _ := claims["iss"].(string); iss != a.config.Issuer {
+		return errors.Errorf("unexpected token issuer %q", iss)
+	}
+
+	audienceFound := false
+	switch aud := claims["aud"].(type) {
+	case string:
+		audienceFound = aud == a.config.Audience
+	case []interface{}:
+		for _, v := range aud {
+			if s, _ := v.(string); s == a.config.Audience {
+				audienceFound = true
+			}
+		}
+	}
+	if !audienceFound {
+		return errors.New("token is not meant for this audience")
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return errors.New("token has no expiry")
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(a.config.Leeway)) {
+		return errors.New("token is expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(a.config.Leeway).Before(time.Unix(int64(nbf), 0)) {
+		return errors.New("token is not valid yet")
+	}
+	return nil
+}
+
+// claimString gives a string or numeric claim as a string, empty when it is missing
+func claimString(claims map[string]interface{}, name string) string {
+	switch v := claims[name].(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	}
+	return ""
+}
+
+// Middleware puts the identity of the Authorization bearer token into the request context.
+// Requests without a bearer token are passed on without one, a token which does not validate is rejected.
+func (a *JWTAuthenticator) Middleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || token == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		id, err := a.Authenticate(token)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			problem.Write(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		serveWithIdentity(w, r, h, id)
+	})
+}
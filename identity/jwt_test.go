@@ -0,0 +1,380 @@
+package identity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testIssuer   = "https://sso.example.com"
+	testAudience = "inventory-access-poc"
+	testLeeway   = 30 * time.Second
+)
+
+var testNow = time.Unix(1700000000, 0)
+
+type testKeys struct {
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+func generateTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testKeys{rsa: rsaKey, ec: ecKey}
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) jwk {
+	return jwk{Kty: "RSA", Kid: kid, Use: "sig", N: encodeBigInt(key.N), E: encodeBigInt(big.NewInt(int64(key.E)))}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) jwk {
+	// coordinates are fixed width in a JWK
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	key.X.FillBytes(x)
+	key.Y.FillBytes(y)
+	return jwk{
+		Kty: "EC",
+		Kid: kid,
+		Use: "sig",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(x),
+		Y:   base64.RawURLEncoding.EncodeToString(y),
+	}
+}
+
+func marshalJWKS(t *testing.T, keys ...jwk) []byte {
+	t.Helper()
+
+	raw, err := json.Marshal(map[string][]jwk{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func newTestAuthenticator(t *testing.T, keys testKeys) *JWTAuthenticator {
+	t.Helper()
+
+	parsed, err := parseJWKS(marshalJWKS(t, rsaJWK("rsa", &keys.rsa.PublicKey), ecJWK("ec", &keys.ec.PublicKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &JWTAuthenticator{
+		config: JWTConfig{
+			Issuer:       testIssuer,
+			Audience:     testAudience,
+			UserClaim:    "sub",
+			AccountClaim: "org_id",
+			Leeway:       testLeeway,
+		},
+		keys: parsed,
+		now:  func() time.Time { return testNow },
+	}
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss":    testIssuer,
+		"aud":    testAudience,
+		"sub":    "user1",
+		"org_id": "12345",
+		"exp":    testNow.Add(time.Hour).Unix(),
+		"nbf":    testNow.Add(-time.Hour).Unix(),
+	}
+}
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// signToken signs the header and claims with the key, which is an RSA or EC private key or nil for no signature
+func signToken(t *testing.T, header jwtHeader, claims map[string]interface{}, key crypto.Signer) string {
+	t.Helper()
+
+	signed := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestAuthenticate(t *testing.T) {
+	keys := generateTestKeys(t)
+	a := newTestAuthenticator(t, keys)
+
+	rs256 := jwtHeader{Alg: "RS256", Kid: "rsa"}
+	es256 := jwtHeader{Alg: "ES256", Kid: "ec"}
+
+	withClaim := func(name string, value interface{}) map[string]interface{} {
+		claims := validClaims()
+		claims[name] = value
+		return claims
+	}
+
+	otherRSA, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		// substring of the expected error, empty when the token is valid
+		err string
+	}{
+		{name: "valid RS256", token: signToken(t, rs256, validClaims(), keys.rsa)},
+		{name: "valid ES256", token: signToken(t, es256, validClaims(), keys.ec)},
+		{name: "audience list", token: signToken(t, rs256, withClaim("aud", []string{"other", testAudience}), keys.rsa)},
+		{
+			name:  "tampered claims",
+			token: tamperClaims(t, signToken(t, rs256, validClaims(), keys.rsa)),
+			err:   "invalid token signature",
+		},
+		{
+			name:  "tampered ES256 signature",
+			token: tamperSignature(t, signToken(t, es256, validClaims(), keys.ec)),
+			err:   "invalid token signature",
+		},
+		{
+			name:  "signed by another key",
+			token: signToken(t, rs256, validClaims(), otherRSA),
+			err:   "invalid token signature",
+		},
+		{
+			name:  "unknown kid",
+			token: signToken(t, jwtHeader{Alg: "RS256", Kid: "missing"}, validClaims(), keys.rsa),
+			err:   "unknown signing key",
+		},
+		{
+			name:  "RS256 header on an EC key",
+			token: signToken(t, jwtHeader{Alg: "RS256", Kid: "ec"}, validClaims(), keys.ec),
+			err:   "is not an RSA key",
+		},
+		{
+			name:  "ES256 header on an RSA key",
+			token: signToken(t, jwtHeader{Alg: "ES256", Kid: "rsa"}, validClaims(), keys.rsa),
+			err:   "is not an EC key",
+		},
+		{
+			name:  "alg none",
+			token: signToken(t, jwtHeader{Alg: "none", Kid: "rsa"}, validClaims(), nil),
+			err:   "unsupported token algorithm",
+		},
+		{
+			name:  "HS256",
+			token: signToken(t, jwtHeader{Alg: "HS256", Kid: "rsa"}, validClaims(), nil),
+			err:   "unsupported token algorithm",
+		},
+		{
+			name:  "wrong issuer",
+			token: signToken(t, rs256, withClaim("iss", "https://evil.example.com"), keys.rsa),
+			err:   "unexpected token issuer",
+		},
+		{
+			name:  "wrong audience",
+			token: signToken(t, rs256, withClaim("aud", "other"), keys.rsa),
+			err:   "not meant for this audience",
+		},
+		{
+			name:  "expired within the leeway",
+			token: signToken(t, rs256, withClaim("exp", testNow.Add(-testLeeway).Unix()), keys.rsa),
+		},
+		{
+			name:  "expired past the leeway",
+			token: signToken(t, rs256, withClaim("exp", testNow.Add(-testLeeway-time.Second).Unix()), keys.rsa),
+			err:   "token is expired",
+		},
+		{
+			name:  "no expiry",
+			token: signToken(t, rs256, withClaim("exp", nil), keys.rsa),
+			err:   "token has no expiry",
+		},
+		{
+			name:  "not valid yet within the leeway",
+			token: signToken(t, rs256, withClaim("nbf", testNow.Add(testLeeway).Unix()), keys.rsa),
+		},
+		{
+			name:  "not valid yet past the leeway",
+			token: signToken(t, rs256, withClaim("nbf", testNow.Add(testLeeway+time.Second).Unix()), keys.rsa),
+			err:   "token is not valid yet",
+		},
+		{
+			name:  "no user",
+			token: signToken(t, rs256, withClaim("sub", nil), keys.rsa),
+			err:   "token has no sub claim",
+		},
+		{name: "malformed", token: "abc.def", err: "malformed token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := a.Authenticate(tt.token)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if id.UserID != "user1" || id.OrgID != "12345" || id.Type != TypeUser {
+					t.Fatalf("unexpected identity %+v", id)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected an error containing %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// tamperClaims replaces the claims of a signed token, keeping its signature
+func tamperClaims(t *testing.T, token string) string {
+	parts := strings.Split(token, ".")
+	claims := validClaims()
+	claims["org_id"] = "99999"
+	parts[1] = encodeSegment(t, claims)
+	return strings.Join(parts, ".")
+}
+
+// tamperSignature flips a bit of the signature of a signed token
+func tamperSignature(t *testing.T, token string) string {
+	parts := strings.Split(token, ".")
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature[len(signature)-1] ^= 1
+	parts[2] = base64.RawURLEncoding.EncodeToString(signature)
+	return strings.Join(parts, ".")
+}
+
+func TestParseJWKS(t *testing.T) {
+	keys := generateTestKeys(t)
+
+	shortRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	offCurve := ecJWK("ec", &keys.ec.PublicKey)
+	offCurve.Y = offCurve.X
+
+	encryption := rsaJWK("enc", &keys.rsa.PublicKey)
+	encryption.Use = "enc"
+
+	p384Key := ecJWK("p384", &keys.ec.PublicKey)
+	p384Key.Crv = "P-384"
+	p384Key.X = encodeBigInt(p384.X)
+	p384Key.Y = encodeBigInt(p384.Y)
+
+	tests := []struct {
+		name string
+		keys []jwk
+		kids []string
+		err  string
+	}{
+		{
+			name: "RSA and EC keys",
+			keys: []jwk{rsaJWK("rsa", &keys.rsa.PublicKey), ecJWK("ec", &keys.ec.PublicKey)},
+			kids: []string{"rsa", "ec"},
+		},
+		{
+			name: "RSA key under 2048 bits",
+			keys: []jwk{rsaJWK("short", &shortRSA.PublicKey), ecJWK("ec", &keys.ec.PublicKey)},
+			err:  "at least 2048 bits",
+		},
+		{
+			name: "EC point off the curve",
+			keys: []jwk{offCurve},
+			err:  "not on the curve",
+		},
+		{
+			name: "encryption and unsupported curve keys are skipped",
+			keys: []jwk{encryption, p384Key, ecJWK("ec", &keys.ec.PublicKey)},
+			kids: []string{"ec"},
+		},
+		{
+			name: "no usable keys",
+			keys: []jwk{encryption},
+			err:  "no usable signing keys",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseJWKS(marshalJWKS(t, tt.keys...))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected an error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(parsed) != len(tt.kids) {
+				t.Fatalf("expected keys %v, got %d keys", tt.kids, len(parsed))
+			}
+			for _, kid := range tt.kids {
+				if _, found := parsed[kid]; !found {
+					t.Fatalf("key %s is missing", kid)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const ContentType = "application/problem+json"
+
+// problem types telling apart the failures a client may want to handle differently
+const (
+	TypeValidation = "/problems/validation"
+	TypeSpiceDB    = "/problems/spicedb"
+	TypeDatabase   = "/problems/database"
+)
+
+// Problem is an RFC 7807 problem document, the body of every error response
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func New(status int, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+func (p Problem) WithType(problemType string) Problem {
+	p.Type = problemType
+	return p
+}
+
+func (p Problem) Write(w http.ResponseWriter) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	_, err = w.Write(body)
+	return err
+}
+
+// Write responds with a problem document of the status
+func Write(w http.ResponseWriter, status int, detail string) error {
+	return New(status, detail).Write(w)
+}
@@ -0,0 +1,398 @@
+package server
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/authzed-go/v1"
+	"github.com/lib/pq"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"gorm.io/gorm"
+)
+
+// how long the access table waits before it is rebuilt after the watch failed
+const accessTableRetry = 5 * time.Second
+
+const createAccessTable = `CREATE TABLE IF NOT EXISTS user_host_access (
+	user_id TEXT NOT NULL,
+	host_id UUID NOT NULL,
+	PRIMARY KEY (user_id, host_id)
+)`
+
+// accessTable keeps user_host_access, the inventory hosts every user can read, in sync with SpiceDB.
+// It is built from LookupResources at a single revision and then follows the Watch stream, recomputing the users
+// affected by each change at the revision the change was made at.
+type accessTable struct {
+	client *authzed.Client
+
+	mu        sync.RWMutex
+	synced    bool
+	revision  string
+	appliedAt time.Time
+	// users with a role binding of their own or through a group, the rows of all of them are recomputed when a
+	// change cannot be narrowed down
+	users map[string]bool
+
+	applyDuration metric.Float64Histogram
+	failures      metric.Int64Counter
+	registration  metric.Registration
+	cancel        context.CancelFunc
+	done          chan struct{}
+}
+
+func newAccessTable(client *authzed.Client) (*accessTable, error) {
+	err := cachecontent.WithTx(func(tx *gorm.DB) error {
+		return tx.Exec(createAccessTable).Error
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create access table")
+	}
+
+	t := &accessTable{client: client}
+
+	meter := otel.Meter("AccessTable")
+	revisionAge, err := meter.Float64ObservableGauge("access_table.revision_age",
+		metric.WithDescription("Time since the access table last applied a SpiceDB revision"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	t.registration, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		if _, appliedAt, synced := t.state(); synced {
+			o.ObserveFloat64(revisionAge, time.Since(appliedAt).Seconds())
+		}
+		return nil
+	}, revisionAge)
+	if err != nil {
+		return nil, err
+	}
+	t.applyDuration, err = meter.Float64Histogram("access_table.apply_duration",
+		metric.WithDescription("Time from receiving a SpiceDB change to committing the recomputed access rows"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	t.failures, err = meter.Int64Counter("access_table.errors",
+		metric.WithDescription("Times the access table failed to build or follow SpiceDB and was rebuilt"))
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// start builds the table and follows the changes in the background until stop
+func (t *accessTable) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	t.done = make(chan struct{})
+
+	go func() {
+		defer close(t.done)
+		for {
+			token, err := t.rebuild(ctx)
+			if err == nil {
+				err = t.watch(ctx, token)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+
+			t.failures.Add(ctx, 1)
+			fmt.Printf("access table: %v, rebuilding in %s\n", err, accessTableRetry)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(accessTableRetry):
+			}
+		}
+	}()
+}
+
+func (t *accessTable) stop() error {
+	if t.cancel != nil {
+		t.cancel()
+		<-t.done
+	}
+	return t.registration.Unregister()
+}
+
+// state gives the last applied revision and when it was applied, synced is false until the table was built
+func (t *accessTable) state() (revision string, appliedAt time.Time, synced bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.revision, t.appliedAt, t.synced
+}
+
+func (t *accessTable) applied(token *v1.ZedToken) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.synced = true
+	t.revision = token.GetToken()
+	t.appliedAt = time.Now()
+}
+
+// rebuild recomputes the rows of every user at the current revision and removes the rows of users who lost all
+// their role bindings, it gives the revision to watch from
+func (t *accessTable) rebuild(ctx context.Context) (*v1.ZedToken, error) {
+	token, err := t.headRevision(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the users, their hosts and the watch all start at the same revision, so that no change falls in between
+	consistency := &v1.Consistency{Requirement: &v1.Consistency_AtExactSnapshot{AtExactSnapshot: token}}
+	users, err := t.readUsers(ctx, consistency)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(users))
+	for user := range users {
+		if err := t.recompute(ctx, user, consistency); err != nil {
+			return nil, err
+		}
+		ids = append(ids, user)
+	}
+
+	err = cachecontent.WithTx(func(tx *gorm.DB) error {
+		return tx.Exec("DELETE FROM user_host_access WHERE user_id <> ALL(?::text[])", pq.Array(ids)).Error
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to remove access of unknown users")
+	}
+
+	t.mu.Lock()
+	t.users = users
+	t.mu.Unlock()
+	t.applied(token)
+
+	return token, nil
+}
+
+// headRevision gives the current revision of SpiceDB. Revisions only come with responses, a fully consistent check
+// of a role binding nobody has gives it even when there are no relationships to read.
+func (t *accessTable) headRevision(ctx context.Context) (*v1.ZedToken, error) {
+	resp, err := t.client.CheckPermission(ctx, &v1.CheckPermissionRequest{
+		Consistency: &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}},
+		Resource: &v1.ObjectReference{
+			ObjectType: "role_binding",
+			ObjectId:   "access_table_revision",
+		},
+		Permission: "subject",
+		Subject: &v1.SubjectReference{
+			Object: &v1.ObjectReference{
+				ObjectType: "user",
+				ObjectId:   "access_table_revision",
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("spicedb error: %v", err)
+	}
+	return resp.GetCheckedAt(), nil
+}
+
+// readUsers gives the users with a role binding, directly or as members of a bound group
+func (t *accessTable) readUsers(ctx context.Context, consistency *v1.Consistency) (map[string]bool, error) {
+	rrClient, err := t.client.ReadRelationships(ctx, &v1.ReadRelationshipsRequest{
+		Consistency: consistency,
+		RelationshipFilter: &v1.RelationshipFilter{
+			ResourceType:     "role_binding",
+			OptionalRelation: "subject",
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("spicedb error: %v", err)
+	}
+
+	users := map[string]bool{}
+	groups := map[string]bool{}
+	for {
+		next, err := rrClient.Recv()
+		if e.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("spicedb error: %v", err)
+		}
+
+		subject := next.GetRelationship().GetSubject().GetObject()
+		switch subject.GetObjectType() {
+		case "user":
+			users[subject.GetObjectId()] = true
+		case "group":
+			groups[subject.GetObjectId()] = true
+		}
+	}
+
+	for group := range groups {
+		if err := t.lookupUsers(ctx, "group", group, "member", consistency, users); err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+// lookupUsers adds the users who have the permission or relation on the object at the consistency, through groups
+// and the hierarchy
+func (t *accessTable) lookupUsers(ctx context.Context, objectType, objectID, permission string, consistency *v1.Consistency, users map[string]bool) error {
+	lsClient, err := t.client.LookupSubjects(ctx, &v1.LookupSubjectsRequest{
+		Consistency: consistency,
+		Resource: &v1.ObjectReference{
+			ObjectType: objectType,
+			ObjectId:   objectID,
+		},
+		Permission:        permission,
+		SubjectObjectType: "user",
+	})
+	if err != nil {
+		return fmt.Errorf("spicedb error: %v", err)
+	}
+
+	for {
+		next, err := lsClient.Recv()
+		if e.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("spicedb error: %v", err)
+		}
+
+		users[next.GetSubject().GetSubjectObjectId()] = true
+	}
+}
+
+// watch applies the changes made after the revision until the stream fails
+func (t *accessTable) watch(ctx context.Context, token *v1.ZedToken) error {
+	wClient, err := t.client.Watch(ctx, &v1.WatchRequest{OptionalStartCursor: token})
+	if err != nil {
+		return fmt.Errorf("spicedb error: %v", err)
+	}
+
+	for {
+		next, err := wClient.Recv()
+		if err != nil {
+			return fmt.Errorf("spicedb error: %v", err)
+		}
+		received := time.Now()
+
+		consistency := &v1.Consistency{Requirement: &v1.Consistency_AtExactSnapshot{AtExactSnapshot: next.GetChangesThrough()}}
+		affected, err := t.affectedUsers(ctx, next.GetUpdates(), consistency)
+		if err != nil {
+			return err
+		}
+		for _, user := range affected {
+			if err := t.recompute(ctx, user, consistency); err != nil {
+				return err
+			}
+		}
+
+		t.applied(next.GetChangesThrough())
+		t.applyDuration.Record(ctx, time.Since(received).Seconds())
+	}
+}
+
+// affectedUsers gives the users whose hosts may have changed, looked up at the revision of the change:
+//   - role binding subjects and group memberships affect the users they name, or the members of the group they name
+//   - grants of a role binding, to a workspace or of a role, affect the subjects of the binding
+//   - moving a host into or out of a workspace affects the users who can read the hosts of the workspace
+//
+// Any other change to the hierarchy recomputes everybody.
+func (t *accessTable) affectedUsers(ctx context.Context, updates []*v1.RelationshipUpdate, consistency *v1.Consistency) ([]string, error) {
+	affected := map[string]bool{}
+	// objects whose users are affected, looked up with the permission or relation
+	type lookup struct {
+		objectType, objectID, permission string
+	}
+	lookups := map[lookup]bool{}
+	all := false
+	for _, update := range updates {
+		rel := update.GetRelationship()
+		resource := rel.GetResource()
+		subject := rel.GetSubject().GetObject()
+
+		switch {
+		case resource.GetObjectType() == "role_binding" && rel.GetRelation() == "subject",
+			resource.GetObjectType() == "group" && rel.GetRelation() == "member":
+			switch subject.GetObjectType() {
+			case "user":
+				affected[subject.GetObjectId()] = true
+			case "group":
+				// a deleted binding or membership leaves the members of the group it named as they were
+				lookups[lookup{"group", subject.GetObjectId(), "member"}] = true
+			default:
+				all = true
+			}
+		case resource.GetObjectType() == "role_binding" && rel.GetRelation() == "granted":
+			lookups[lookup{"role_binding", resource.GetObjectId(), "subject"}] = true
+		case rel.GetRelation() == "user_grant" && subject.GetObjectType() == "role_binding":
+			lookups[lookup{"role_binding", subject.GetObjectId(), "subject"}] = true
+		case resource.GetObjectType() == "inventory/host" && rel.GetRelation() == "workspace":
+			// the workspace itself is unchanged, its readers are the same before and after the host moved
+			for _, permission := range hostReadWorkspacePermissions {
+				lookups[lookup{"workspace", subject.GetObjectId(), permission}] = true
+			}
+		default:
+			all = true
+		}
+	}
+
+	// looked up even when everybody is recomputed, the users found may not be known yet
+	for l := range lookups {
+		if err := t.lookupUsers(ctx, l.objectType, l.objectID, l.permission, consistency, affected); err != nil {
+			return nil, err
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// users are kept after they lose their last binding, their rows are recomputed to nothing
+	for user := range affected {
+		t.users[user] = true
+	}
+	if all {
+		affected = t.users
+	}
+
+	users := make([]string, 0, len(affected))
+	for user := range affected {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// recompute replaces the rows of the user with the hosts they can read at the consistency
+func (t *accessTable) recompute(ctx context.Context, user string, consistency *v1.Consistency) error {
+	hostIDs, err := lookupResourceIDs(ctx, t.client, &v1.LookupResourcesRequest{
+		Consistency:        consistency,
+		ResourceObjectType: "inventory/host",
+		Permission:         "read",
+		Subject: &v1.SubjectReference{
+			Object: &v1.ObjectReference{
+				ObjectType: "user",
+				ObjectId:   user,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = cachecontent.WithTx(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM user_host_access WHERE user_id = ?", user).Error; err != nil {
+			return err
+		}
+		return tx.Exec("INSERT INTO user_host_access (user_id, host_id) SELECT ?, unnest(?::uuid[])", user, pq.Array(hostIDs)).Error
+	})
+	return errors.Wrapf(err, "failed to update access of user %s", user)
+}
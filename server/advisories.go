@@ -0,0 +1,245 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	advisoriesPath = "/content/advisories"
+
+	// system_advisories.status_id of advisories which can be installed from the repos the system has enabled
+	advisoryStatusInstallable = 0
+)
+
+// sort_key values accepted by /content/advisories mapped to the columns of the per-advisory aggregate
+var advisorySortColumns = map[string]string{
+	"name":                "advs.name",
+	"synopsis":            "advs.synopsis",
+	"advisory_type":       "advs.advisory_type_id",
+	"severity":            "advs.severity_id",
+	"public_date":         "advs.public_date",
+	"systems_installable": "advs.systems_installable",
+	"systems_applicable":  "advs.systems_applicable",
+}
+
+// AdvisoryItem is a row of the advisory list
+type AdvisoryItem struct {
+	ID             int64      `gorm:"column:id" json:"id"`
+	Name           string     `gorm:"column:name" json:"name"`
+	Synopsis       string     `gorm:"column:synopsis" json:"synopsis"`
+	AdvisoryTypeID int        `gorm:"column:advisory_type_id" json:"-"`
+	AdvisoryType   string     `gorm:"-" json:"advisory_type"`
+	SeverityID     *int       `gorm:"column:severity_id" json:"severity"`
+	PublicDate     *time.Time `gorm:"column:public_date" json:"public_date"`
+	// systems the advisory can be installed on and systems it applies to, the latter including the former
+	SystemsInstallable int `gorm:"column:systems_installable" json:"systems_installable"`
+	SystemsApplicable  int `gorm:"column:systems_applicable" json:"systems_applicable"`
+}
+
+type AdvisoriesPayload struct {
+	Data  []AdvisoryItem `json:"data"`
+	Meta  ListMeta       `json:"meta"`
+	Links ListLinks      `json:"links"`
+}
+
+func (p AdvisoriesPayload) VisitGetContentAdvisoriesResponse(w http.ResponseWriter) error {
+	return writeJSON(w, p)
+}
+
+type advisoriesOptions struct {
+	listOptions
+	Matches        string
+	AdvisoryTypeID *int
+	SeverityID     *int
+	PublicDateFrom *time.Time
+	PublicDateTo   *time.Time
+}
+
+func getAdvisoriesOptions(params api.GetContentAdvisoriesParams) (advisoriesOptions, error) {
+	list, err := getListOptions(params.Page, params.Limit, params.SortKey, params.SortOrder, advisorySortColumns, "name")
+	if err != nil {
+		return advisoriesOptions{}, err
+	}
+
+	opts := advisoriesOptions{
+		listOptions:    list,
+		PublicDateFrom: params.PublicDateFrom,
+		PublicDateTo:   params.PublicDateTo,
+	}
+
+	if params.Matches != nil && *params.Matches != "" {
+		opts.Matches = *params.Matches
+		opts.Filter["matches"] = opts.Matches
+	}
+
+	if params.AdvisoryType != nil {
+		id, err := advisoryTypeID(*params.AdvisoryType)
+		if err != nil {
+			return opts, err
+		}
+		opts.AdvisoryTypeID = &id
+		opts.Filter["advisory_type"] = *params.AdvisoryType
+	}
+
+	if params.Severity != nil {
+		if err := checkSeverity(*params.Severity); err != nil {
+			return opts, err
+		}
+		opts.SeverityID = params.Severity
+		opts.Filter["severity"] = strconv.Itoa(*params.Severity)
+	}
+
+	if opts.PublicDateFrom != nil {
+		opts.Filter["public_date_from"] = opts.PublicDateFrom.Format(time.RFC3339)
+	}
+	if opts.PublicDateTo != nil {
+		opts.Filter["public_date_to"] = opts.PublicDateTo.Format(time.RFC3339)
+	}
+	if opts.PublicDateFrom != nil && opts.PublicDateTo != nil && opts.PublicDateTo.Before(*opts.PublicDateFrom) {
+		return opts, errors.New("public_date_to is before public_date_from")
+	}
+
+	return opts, nil
+}
+
+// advisoryTypeID looks the advisory type name up in the types loaded from the database
+func advisoryTypeID(name string) (int, error) {
+	names := make([]string, 0, len(cachecontent.AdvisoryTypes))
+	for id, typeName := range cachecontent.AdvisoryTypes {
+		if strings.EqualFold(typeName, name) {
+			return id, nil
+		}
+		names = append(names, typeName)
+	}
+
+	sort.Strings(names)
+	return 0, errors.Errorf("invalid advisory_type %q, must be one of %s", name, strings.Join(names, ", "))
+}
+
+// checkSeverity checks the severity id against the severities loaded from the database
+func checkSeverity(id int) error {
+	if _, found := cachecontent.AdvisorySeverities[id]; found {
+		return nil
+	}
+
+	ids := make([]int, 0, len(cachecontent.AdvisorySeverities))
+	for severityID := range cachecontent.AdvisorySeverities {
+		ids = append(ids, severityID)
+	}
+	sort.Ints(ids)
+
+	valid := make([]string, 0, len(ids))
+	for _, severityID := range ids {
+		valid = append(valid, fmt.Sprintf("%d (%s)", severityID, cachecontent.AdvisorySeverities[severityID]))
+	}
+	return errors.Errorf("invalid severity %d, must be one of %s", id, strings.Join(valid, ", "))
+}
+
+func (c *PreFilterServer) GetContentAdvisories(ctx context.Context, request api.GetContentAdvisoriesRequestObject) (api.GetContentAdvisoriesResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentAdvisories")
+	defer span.End()
+
+	opts, err := getAdvisoriesOptions(request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	hostIDs, err := c.lookupHostIDs(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return advisories(accountId, hostIDsFilter(hostIDs), opts)
+}
+
+func (c *BaselineServer) GetContentAdvisories(ctx context.Context, request api.GetContentAdvisoriesRequestObject) (api.GetContentAdvisoriesResponseObject, error) {
+	opts, err := getAdvisoriesOptions(request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	_, accountId, _ := getIdentityFromContext(ctx)
+
+	ctx, span := c.Tracer.Start(ctx, "GetContentAdvisories")
+	defer span.End()
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return advisories(accountId, ungroupedHostsFilter, opts)
+}
+
+// advisories lists the advisories applicable to the systems of the account passing the host filter,
+// counting the systems per advisory
+func advisories(accID int64, filter hostFilter, opts advisoriesOptions) (AdvisoriesPayload, error) {
+	var total int64
+	items := make([]AdvisoryItem, 0, opts.Limit)
+
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		q := tx.Table("system_advisories sa").
+			Select(`
+				am.id id,
+				am.name name,
+				am.synopsis synopsis,
+				am.advisory_type_id advisory_type_id,
+				am.severity_id severity_id,
+				am.public_date public_date,
+				count(*) filter (where sa.status_id = ?) as systems_installable,
+				count(*) as systems_applicable
+			`, advisoryStatusInstallable).
+			Joins("JOIN system_platform sp ON sa.system_id = sp.id AND sa.rh_account_id = sp.rh_account_id").
+			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
+			Joins("JOIN advisory_metadata am ON sa.advisory_id = am.id").
+			Where("sa.rh_account_id = ?", accID)
+		q = filter(q)
+
+		if opts.Matches != "" {
+			q = q.Where("am.name ILIKE ?", "%"+escapeLike(opts.Matches)+"%")
+		}
+		if opts.AdvisoryTypeID != nil {
+			q = q.Where("am.advisory_type_id = ?", *opts.AdvisoryTypeID)
+		}
+		if opts.SeverityID != nil {
+			q = q.Where("am.severity_id = ?", *opts.SeverityID)
+		}
+		if opts.PublicDateFrom != nil {
+			q = q.Where("am.public_date >= ?", *opts.PublicDateFrom)
+		}
+		if opts.PublicDateTo != nil {
+			q = q.Where("am.public_date <= ?", *opts.PublicDateTo)
+		}
+
+		q = q.Group("am.id, am.name, am.synopsis, am.advisory_type_id, am.severity_id, am.public_date")
+
+		return opts.fetchPage(tx.Table("(?) AS advs", q), "advs.*", "advs.id", &total, &items)
+	})
+	if err != nil {
+		return AdvisoriesPayload{}, databaseError(err, "failed to get advisories")
+	}
+
+	for i := range items {
+		items[i].AdvisoryType = cachecontent.AdvisoryTypes[items[i].AdvisoryTypeID]
+	}
+
+	return AdvisoriesPayload{
+		Data:  items,
+		Meta:  opts.meta(total),
+		Links: opts.links(advisoriesPath, total),
+	}, nil
+}
@@ -0,0 +1,311 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var advisorySystemSortColumns = map[string]string{
+	"display_name":   "sp.display_name",
+	"status_id":      "sa.status_id",
+	"first_reported": "sa.first_reported",
+}
+
+// advisory statuses by system_advisories.status_id
+var advisoryStatuses = map[int]string{
+	0: "Installable",
+	1: "Applicable",
+}
+
+// AdvisoryDetail is an advisory with the counts of the accessible systems it applies to
+type AdvisoryDetail struct {
+	ID                 int64           `json:"id"`
+	Name               string          `json:"name"`
+	Synopsis           string          `json:"synopsis"`
+	Summary            string          `json:"summary"`
+	Description        string          `json:"description"`
+	Solution           *string         `json:"solution"`
+	AdvisoryType       string          `json:"advisory_type"`
+	Severity           *int            `json:"severity"`
+	PublicDate         time.Time       `json:"public_date"`
+	ModifiedDate       time.Time       `json:"modified_date"`
+	URL                *string         `json:"url"`
+	CveList            []string        `json:"cve_list"`
+	RebootRequired     bool            `json:"reboot_required"`
+	PackageData        json.RawMessage `json:"package_data"`
+	SystemsInstallable int             `json:"systems_installable"`
+	SystemsApplicable  int             `json:"systems_applicable"`
+}
+
+func (p AdvisoryDetail) VisitGetContentAdvisoriesAdvisoryNameResponse(w http.ResponseWriter) error {
+	return writeJSON(w, p)
+}
+
+func (r notFoundResponse) VisitGetContentAdvisoriesAdvisoryNameResponse(w http.ResponseWriter) error {
+	return r.visit(w)
+}
+
+// AdvisorySystemItem is a system affected by an advisory
+type AdvisorySystemItem struct {
+	InventoryID   string    `gorm:"column:inventory_id" json:"inventory_id"`
+	DisplayName   string    `gorm:"column:display_name" json:"display_name"`
+	StatusID      int       `gorm:"column:status_id" json:"status_id"`
+	Status        string    `gorm:"-" json:"status"`
+	FirstReported time.Time `gorm:"column:first_reported" json:"first_reported"`
+}
+
+type AdvisorySystemsPayload struct {
+	Data  []AdvisorySystemItem `json:"data"`
+	Meta  ListMeta             `json:"meta"`
+	Links ListLinks            `json:"links"`
+}
+
+func (p AdvisorySystemsPayload) VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w http.ResponseWriter) error {
+	return writeJSON(w, p)
+}
+
+func (r notFoundResponse) VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w http.ResponseWriter) error {
+	return r.visit(w)
+}
+
+type advisorySystemsOptions struct {
+	listOptions
+	AdvisoryName string
+	StatusID     *int
+}
+
+func getAdvisorySystemsOptions(request api.GetContentAdvisoriesAdvisoryNameSystemsRequestObject) (advisorySystemsOptions, error) {
+	params := request.Params
+	list, err := getListOptions(params.Page, params.Limit, params.SortKey, params.SortOrder, advisorySystemSortColumns, "display_name")
+	if err != nil {
+		return advisorySystemsOptions{}, err
+	}
+
+	opts := advisorySystemsOptions{
+		listOptions:  list,
+		AdvisoryName: request.AdvisoryName,
+	}
+	if params.StatusId != nil {
+		if _, ok := advisoryStatuses[*params.StatusId]; !ok {
+			return opts, errors.Errorf("invalid status_id %d", *params.StatusId)
+		}
+		opts.StatusID = params.StatusId
+		opts.Filter["status_id"] = strconv.Itoa(*params.StatusId)
+	}
+
+	return opts, nil
+}
+
+func (o advisorySystemsOptions) path() string {
+	return fmt.Sprintf("%s/%s/systems", advisoriesPath, url.PathEscape(o.AdvisoryName))
+}
+
+func (c *PreFilterServer) GetContentAdvisoriesAdvisoryName(ctx context.Context, request api.GetContentAdvisoriesAdvisoryNameRequestObject) (api.GetContentAdvisoriesAdvisoryNameResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentAdvisoriesAdvisoryName")
+	defer span.End()
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	hostIDs, err := c.lookupHostIDs(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return advisoryDetail(accountId, hostIDsFilter(hostIDs), request.AdvisoryName)
+}
+
+func (c *BaselineServer) GetContentAdvisoriesAdvisoryName(ctx context.Context, request api.GetContentAdvisoriesAdvisoryNameRequestObject) (api.GetContentAdvisoriesAdvisoryNameResponseObject, error) {
+	_, accountId, _ := getIdentityFromContext(ctx)
+
+	ctx, span := c.Tracer.Start(ctx, "GetContentAdvisoriesAdvisoryName")
+	defer span.End()
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return advisoryDetail(accountId, ungroupedHostsFilter, request.AdvisoryName)
+}
+
+func (c *PreFilterServer) GetContentAdvisoriesAdvisoryNameSystems(ctx context.Context, request api.GetContentAdvisoriesAdvisoryNameSystemsRequestObject) (api.GetContentAdvisoriesAdvisoryNameSystemsResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentAdvisoriesAdvisoryNameSystems")
+	defer span.End()
+
+	opts, err := getAdvisorySystemsOptions(request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+	opts.setSystemProfile(systemProfileFilters(ctx))
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	hostIDs, err := c.lookupHostIDs(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return advisorySystems(accountId, hostIDsFilter(hostIDs), opts)
+}
+
+func (c *BaselineServer) GetContentAdvisoriesAdvisoryNameSystems(ctx context.Context, request api.GetContentAdvisoriesAdvisoryNameSystemsRequestObject) (api.GetContentAdvisoriesAdvisoryNameSystemsResponseObject, error) {
+	opts, err := getAdvisorySystemsOptions(request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+	opts.setSystemProfile(systemProfileFilters(ctx))
+
+	_, accountId, _ := getIdentityFromContext(ctx)
+
+	ctx, span := c.Tracer.Start(ctx, "GetContentAdvisoriesAdvisoryNameSystems")
+	defer span.End()
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return advisorySystems(accountId, ungroupedHostsFilter, opts)
+}
+
+func findAdvisory(tx *gorm.DB, name string) (*cachecontent.AdvisoryMetadata, error) {
+	advisory := cachecontent.AdvisoryMetadata{}
+	result := tx.Where("name = ?", name).Limit(1).Find(&advisory)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &advisory, nil
+}
+
+// advisoryDetail returns the advisory if it applies to at least one system of the account passing the host filter.
+// Advisories applying only to systems the user cannot read are reported as not found.
+func advisoryDetail(accID int64, filter hostFilter, name string) (api.GetContentAdvisoriesAdvisoryNameResponseObject, error) {
+	var advisory *cachecontent.AdvisoryMetadata
+	counts := struct {
+		SystemsInstallable int
+		SystemsApplicable  int
+	}{}
+
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		var err error
+		advisory, err = findAdvisory(tx, name)
+		if err != nil || advisory == nil {
+			return err
+		}
+
+		q := tx.Table("system_advisories sa").
+			Select(`
+				count(*) filter (where sa.status_id = ?) as systems_installable,
+				count(*) as systems_applicable
+			`, advisoryStatusInstallable).
+			Joins("JOIN system_platform sp ON sa.system_id = sp.id AND sa.rh_account_id = sp.rh_account_id").
+			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
+			Where("sa.rh_account_id = ?", accID).
+			Where("sa.advisory_id = ?", advisory.ID)
+
+		return filter(q).Scan(&counts).Error
+	})
+	if err != nil {
+		return nil, databaseError(err, "failed to get advisory")
+	}
+
+	if advisory == nil || counts.SystemsApplicable == 0 {
+		return notFoundResponse{Error: fmt.Sprintf("advisory %s not found", name)}, nil
+	}
+
+	var cves []string
+	if len(advisory.CveList) > 0 {
+		if err = json.Unmarshal(advisory.CveList, &cves); err != nil {
+			return nil, errors.Wrap(err, "invalid cve_list")
+		}
+	}
+	if cves == nil {
+		cves = []string{}
+	}
+
+	packageData := json.RawMessage("null")
+	if len(advisory.PackageData) > 0 {
+		packageData = advisory.PackageData
+	}
+
+	return AdvisoryDetail{
+		ID:                 advisory.ID,
+		Name:               advisory.Name,
+		Synopsis:           advisory.Synopsis,
+		Summary:            advisory.Summary,
+		Description:        advisory.Description,
+		Solution:           advisory.Solution,
+		AdvisoryType:       cachecontent.AdvisoryTypes[advisory.AdvisoryTypeID],
+		Severity:           advisory.SeverityID,
+		PublicDate:         advisory.PublicDate,
+		ModifiedDate:       advisory.ModifiedDate,
+		URL:                advisory.URL,
+		CveList:            cves,
+		RebootRequired:     advisory.RebootRequired,
+		PackageData:        packageData,
+		SystemsInstallable: counts.SystemsInstallable,
+		SystemsApplicable:  counts.SystemsApplicable,
+	}, nil
+}
+
+// advisorySystems lists the systems of the account passing the host filter which the advisory applies to
+func advisorySystems(accID int64, filter hostFilter, opts advisorySystemsOptions) (api.GetContentAdvisoriesAdvisoryNameSystemsResponseObject, error) {
+	var advisory *cachecontent.AdvisoryMetadata
+	var total int64
+	items := make([]AdvisorySystemItem, 0, opts.Limit)
+
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		var err error
+		advisory, err = findAdvisory(tx, opts.AdvisoryName)
+		if err != nil || advisory == nil {
+			return err
+		}
+
+		q := tx.Table("system_advisories sa").
+			Joins("JOIN system_platform sp ON sa.system_id = sp.id AND sa.rh_account_id = sp.rh_account_id").
+			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
+			Where("sa.rh_account_id = ?", accID).
+			Where("sa.advisory_id = ?", advisory.ID)
+		q = filterSystemProfile(filter(q), opts.SystemProfile)
+
+		if opts.StatusID != nil {
+			q = q.Where("sa.status_id = ?", *opts.StatusID)
+		}
+
+		return opts.fetchPage(q, `
+			sp.inventory_id inventory_id,
+			sp.display_name display_name,
+			sa.status_id status_id,
+			sa.first_reported first_reported
+		`, "sp.id", &total, &items)
+	})
+	if err != nil {
+		return nil, databaseError(err, "failed to get advisory systems")
+	}
+
+	if advisory == nil {
+		return notFoundResponse{Error: fmt.Sprintf("advisory %s not found", opts.AdvisoryName)}, nil
+	}
+
+	for i := range items {
+		items[i].Status = advisoryStatuses[items[i].StatusID]
+	}
+
+	return AdvisorySystemsPayload{
+		Data:  items,
+		Meta:  opts.meta(total),
+		Links: opts.links(opts.path(), total),
+	}, nil
+}
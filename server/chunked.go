@@ -0,0 +1,223 @@
+package server
+
+import (
+	"context"
+	e "errors"
+	"io"
+	"sync"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/lib/pq"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+)
+
+// LookupChunkSizeHeader sets the number of hosts looked up and aggregated at once by the chunked join strategy
+const LookupChunkSizeHeader = "Lookup-Chunk-Size"
+
+const (
+	defaultLookupChunkSize = 1000
+	maxLookupChunkSize     = 100000
+	// chunk aggregates running at the same time, further chunks wait for one of them to finish
+	maxParallelChunks = 8
+)
+
+type lookupChunkSizeContextKey struct{}
+
+func extractLookupChunkSize(ctx context.Context) int {
+	size, ok := ctx.Value(lookupChunkSizeContextKey{}).(int)
+	if !ok {
+		return defaultLookupChunkSize
+	}
+	return size
+}
+
+// packagesByHostChunks pages through the hosts the user can read and aggregates the packages of each chunk of hosts
+// while the next one is looked up. The partial counts are merged and the merged counts are filtered, sorted and
+// paged in the database like the other strategies do.
+func packagesByHostChunks(ctx context.Context, c *PreFilterServer, page *packagePage, accID int64, user string, opts packageListOptions) error {
+	chunkSize := extractLookupChunkSize(ctx)
+
+	ctx, span := c.Tracer.Start(ctx, "Chunked pre-filter")
+	defer span.End()
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxParallelChunks)
+
+	var mu sync.Mutex
+	merged := mergedPackages{items: map[int64]*PackageItem{}}
+	// LookupResources may return a host more than once, a host is only aggregated in the first chunk it is in
+	seen := map[string]bool{}
+	chunks := 0
+	lookupErr := c.lookupHostChunks(gctx, user, chunkSize, func(hostIDs []string) {
+		unseen := hostIDs[:0]
+		for _, id := range hostIDs {
+			if !seen[id] {
+				seen[id] = true
+				unseen = append(unseen, id)
+			}
+		}
+		if len(unseen) == 0 {
+			return
+		}
+		hostIDs = unseen
+
+		chunks++
+		g.Go(func() error {
+			_, pgSpan := c.Tracer.Start(gctx, "Postgres chunk query")
+			defer pgSpan.End()
+
+			items, err := packageChunk(accID, hostIDs, opts)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			merged.add(items)
+			mu.Unlock()
+			return nil
+		})
+	})
+	// a failed chunk cancels the lookup, its error is the one to report
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if lookupErr != nil {
+		return lookupErr
+	}
+	span.SetAttributes(attribute.Int("lookup.chunk_size", chunkSize), attribute.Int("lookup.chunks", chunks))
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		return fetchPackagePage(tx, merged.aggregate(tx, accID), opts, page)
+	})
+	return databaseError(err, "failed to get counts")
+}
+
+// lookupHostChunks looks up the hosts the user can read in pages of chunkSize and hands each page to chunk.
+// All the pages are read at the revision of the first one.
+func (c *PreFilterServer) lookupHostChunks(ctx context.Context, user string, chunkSize int, chunk func(hostIDs []string)) error {
+	var consistency *v1.Consistency
+	var cursor *v1.Cursor
+	for {
+		lrClient, err := c.SpicedbClient.LookupResources(ctx, &v1.LookupResourcesRequest{
+			Consistency:        consistency,
+			ResourceObjectType: "inventory/host",
+			Permission:         "read",
+			Subject: &v1.SubjectReference{
+				Object: &v1.ObjectReference{
+					ObjectType: "user",
+					ObjectId:   user,
+				},
+			},
+			OptionalLimit:  uint32(chunkSize),
+			OptionalCursor: cursor,
+		})
+		if err != nil {
+			return spicedbError(err)
+		}
+
+		hostIDs := make([]string, 0, chunkSize)
+		for {
+			next, err := lrClient.Recv()
+			if e.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return spicedbError(err)
+			}
+
+			hostIDs = append(hostIDs, next.GetResourceObjectId())
+			cursor = next.GetAfterResultCursor()
+			if consistency == nil {
+				consistency = &v1.Consistency{Requirement: &v1.Consistency_AtExactSnapshot{AtExactSnapshot: next.GetLookedUpAt()}}
+			}
+		}
+
+		if len(hostIDs) > 0 {
+			chunk(hostIDs)
+		}
+		if len(hostIDs) < chunkSize {
+			return nil
+		}
+	}
+}
+
+// packageChunk aggregates the packages of a chunk of hosts. The filters on the aggregate are left to the query of
+// the merged counts, patches_available can only be decided on them.
+func packageChunk(accID int64, hostIDs []string, opts packageListOptions) ([]PackageItem, error) {
+	var items []PackageItem
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		return hostIDsFilter(hostIDs)(packageAggregate(tx, accID, opts)).Find(&items).Error
+	})
+
+	return items, databaseError(err, "failed to get counts")
+}
+
+// mergedPackages are package counts added up outside of the database, from the chunks of the chunked strategy or
+// the permitted rows of the post-filter experiment
+type mergedPackages struct {
+	items map[int64]*PackageItem
+	// candidate evras per package, the newest of them is picked in the database which knows the "numeric" collation
+	evraIDs []int64
+	evras   []string
+}
+
+// add adds the counts of a chunk to the merged counts, the chunks have to hold disjoint hosts
+func (m *mergedPackages) add(items []PackageItem) {
+	for i := range items {
+		item := &items[i]
+
+		if item.LatestEVRA != nil {
+			m.evraIDs = append(m.evraIDs, item.PkgNameID)
+			m.evras = append(m.evras, *item.LatestEVRA)
+		}
+
+		merged, found := m.items[item.PkgNameID]
+		if !found {
+			m.items[item.PkgNameID] = item
+			continue
+		}
+
+		merged.SysInstalled += item.SysInstalled
+		merged.SysInstallable += item.SysInstallable
+		merged.SysApplicable += item.SysApplicable
+	}
+}
+
+// aggregate is a per-package aggregate of the merged counts for fetchPackagePage
+func (m *mergedPackages) aggregate(tx *gorm.DB, accID int64) *gorm.DB {
+	ids := make([]int64, 0, len(m.items))
+	installed := make([]int64, 0, len(m.items))
+	installable := make([]int64, 0, len(m.items))
+	applicable := make([]int64, 0, len(m.items))
+	for id, item := range m.items {
+		ids = append(ids, id)
+		installed = append(installed, int64(item.SysInstalled))
+		installable = append(installable, int64(item.SysInstallable))
+		applicable = append(applicable, int64(item.SysApplicable))
+	}
+
+	newest := tx.Table("unnest(?::bigint[], ?::text[]) AS e(package_name_id, evra)", pq.Array(m.evraIDs), pq.Array(m.evras)).
+		Select(`e.package_name_id, max(e.evra COLLATE "numeric") latest_evra`).
+		Group("e.package_name_id")
+
+	return tx.Table(`unnest(?::bigint[], ?::bigint[], ?::bigint[], ?::bigint[])
+			AS c(package_name_id, systems_installed, systems_installable, systems_applicable)`,
+		pq.Array(ids), pq.Array(installed), pq.Array(installable), pq.Array(applicable)).
+		Select(`
+			?::bigint rh_account_id,
+			c.package_name_id package_name_id,
+			pn.name name,
+			e.latest_evra latest_evra,
+			c.systems_installed systems_installed,
+			c.systems_installable systems_installable,
+			c.systems_applicable systems_applicable
+		`, accID).
+		Joins("JOIN package_name pn ON c.package_name_id = pn.id").
+		Joins("LEFT JOIN (?) e ON c.package_name_id = e.package_name_id", newest)
+}
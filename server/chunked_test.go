@@ -0,0 +1,86 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+// packageCounts gives a package with the counts and the newest evra of a chunk
+func packageCounts(id int64, installed, installable, applicable int, evra string) PackageItem {
+	item := PackageItem{}
+	item.PkgNameID = id
+	item.SysInstalled = installed
+	item.SysInstallable = installable
+	item.SysApplicable = applicable
+	if evra != "" {
+		item.LatestEVRA = &evra
+	}
+	return item
+}
+
+// counts are the installed, installable and applicable counts of a merged package
+func counts(m mergedPackages, id int64) [3]int {
+	item, found := m.items[id]
+	if !found {
+		return [3]int{}
+	}
+	return [3]int{item.SysInstalled, item.SysInstallable, item.SysApplicable}
+}
+
+func TestMergedPackagesAdd(t *testing.T) {
+	merged := mergedPackages{items: map[int64]*PackageItem{}}
+	merged.add([]PackageItem{
+		packageCounts(1, 3, 1, 2, "1.0-1.el9.x86_64"),
+		packageCounts(2, 1, 0, 0, ""),
+	})
+	merged.add([]PackageItem{
+		packageCounts(1, 2, 2, 2, "1.0-10.el9.x86_64"),
+		packageCounts(3, 4, 0, 1, "2.0-1.el9.x86_64"),
+	})
+	merged.add(nil)
+
+	if len(merged.items) != 3 {
+		t.Fatalf("expected 3 packages, got %d", len(merged.items))
+	}
+	for id, want := range map[int64][3]int{1: {5, 3, 4}, 2: {1, 0, 0}, 3: {4, 0, 1}} {
+		if got := counts(merged, id); got != want {
+			t.Fatalf("expected the counts %v for package %d, got %v", want, id, got)
+		}
+	}
+
+	// the newest evra is picked in the database, every candidate has to be kept
+	wantIDs := []int64{1, 1, 3}
+	wantEVRAs := []string{"1.0-1.el9.x86_64", "1.0-10.el9.x86_64", "2.0-1.el9.x86_64"}
+	if !reflect.DeepEqual(merged.evraIDs, wantIDs) || !reflect.DeepEqual(merged.evras, wantEVRAs) {
+		t.Fatalf("expected the evras %v %v, got %v %v", wantIDs, wantEVRAs, merged.evraIDs, merged.evras)
+	}
+}
+
+func TestAggregatePackageRows(t *testing.T) {
+	evra := func(s string) *string { return &s }
+	rows := []systemPackageRow{
+		{InventoryID: "host1", PackageNameID: 1, LatestEVRA: evra("1.0-2"), UpdateStatus: "Installable"},
+		{InventoryID: "host2", PackageNameID: 1, LatestEVRA: evra("1.0-2"), UpdateStatus: "Applicable"},
+		{InventoryID: "host3", PackageNameID: 1, LatestEVRA: evra("1.0-3"), UpdateStatus: "None"},
+		{InventoryID: "host1", PackageNameID: 2, UpdateStatus: "None"},
+		{InventoryID: "host4", PackageNameID: 3, LatestEVRA: evra("3.0-1"), UpdateStatus: "Installable"},
+	}
+	permitted := map[string]bool{"host1": true, "host2": true, "host3": true}
+
+	merged := aggregatePackageRows(rows, permitted)
+
+	if len(merged.items) != 2 {
+		t.Fatalf("expected the packages of the permitted hosts only, got %d", len(merged.items))
+	}
+	for id, want := range map[int64][3]int{1: {3, 1, 2}, 2: {1, 0, 0}} {
+		if got := counts(merged, id); got != want {
+			t.Fatalf("expected the counts %v for package %d, got %v", want, id, got)
+		}
+	}
+
+	wantIDs := []int64{1, 1}
+	wantEVRAs := []string{"1.0-2", "1.0-3"}
+	if !reflect.DeepEqual(merged.evraIDs, wantIDs) || !reflect.DeepEqual(merged.evras, wantEVRAs) {
+		t.Fatalf("expected each evra once, %v %v, got %v %v", wantIDs, wantEVRAs, merged.evraIDs, merged.evras)
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	e "errors"
+	"fmt"
+	"net/http"
+
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/problem"
+	"github.com/pkg/errors"
+)
+
+// handlerError is an error returned by a handler which the response error handler turns into a problem document.
+// The detail of the document is the error itself unless a fixed detail is set, which errors of the upstream services
+// do so that their queries and addresses stay in the log.
+type handlerError struct {
+	problemType string
+	status      int
+	detail      string
+	err         error
+}
+
+func (h *handlerError) Error() string {
+	return h.err.Error()
+}
+
+func (h *handlerError) Unwrap() error {
+	return h.err
+}
+
+// invalidRequest marks an error in the request parameters
+func invalidRequest(err error) error {
+	return &handlerError{problemType: problem.TypeValidation, status: http.StatusBadRequest, err: err}
+}
+
+func spicedbError(err error) error {
+	return &handlerError{
+		problemType: problem.TypeSpiceDB,
+		status:      http.StatusServiceUnavailable,
+		detail:      "authorization service unavailable",
+		err:         fmt.Errorf("spicedb error: %v", err),
+	}
+}
+
+// databaseError wraps a failed query with the message, nil when the query succeeded
+func databaseError(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &handlerError{
+		problemType: problem.TypeDatabase,
+		status:      http.StatusInternalServerError,
+		detail:      "database error",
+		err:         errors.Wrap(err, message),
+	}
+}
+
+// strictServerOptions makes the strict handlers answer errors with problem documents
+var strictServerOptions = api.StrictHTTPServerOptions{
+	RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+		problem.New(http.StatusBadRequest, err.Error()).WithType(problem.TypeValidation).Write(w)
+	},
+	ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+		fmt.Printf("%s %s failed: %v\n", r.Method, r.URL.Path, err)
+
+		var herr *handlerError
+		if e.As(err, &herr) {
+			detail := herr.detail
+			if detail == "" {
+				detail = herr.Error()
+			}
+			problem.New(herr.status, detail).WithType(herr.problemType).Write(w)
+			return
+		}
+		problem.Write(w, http.StatusInternalServerError, "internal error")
+	},
+}
+
+// chiServerOptions answers invalid path and query parameters with problem documents
+var chiServerOptions = api.ChiServerOptions{
+	ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+		problem.New(http.StatusBadRequest, err.Error()).WithType(problem.TypeValidation).Write(w)
+	},
+}
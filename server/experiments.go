@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/experiment"
+)
+
+// experiments are registered in this order, the experiment package lists them by name
+var experiments = []*serverExperiment{
+	{
+		name: "pre-filter",
+		description: "Looks up the hosts the user can read with SpiceDB LookupResources and restricts the content " +
+			"queries to them, metering the SpiceDB call together with the query.",
+		requires: []experiment.Dependency{experiment.SpiceDB},
+		newServer: func(base PreFilterServer) api.StrictServerInterface {
+			return &base
+		},
+	},
+	{
+		name: "baseline",
+		description: "Restricts the content queries to the ungrouped hosts of the account without asking SpiceDB, " +
+			"like the current inventory groups filtering.",
+		// entitlements and single system checks still ask SpiceDB outside of the metered part
+		requires: []experiment.Dependency{experiment.SpiceDB},
+		newServer: func(base PreFilterServer) api.StrictServerInterface {
+			srv := BaselineServer(base)
+			return &srv
+		},
+	},
+	{
+		name: "post-filter",
+		description: "Reads the package rows of all the systems of the account and keeps the hosts SpiceDB permits " +
+			"in batched BulkCheckPermission calls, the package list is aggregated from the permitted rows. Other " +
+			"endpoints are answered like in pre-filter.",
+		requires: []experiment.Dependency{experiment.SpiceDB, experiment.SpiceDBExperimental},
+		setup: func(ctx context.Context, deps experiment.Dependencies) (api.StrictServerInterface, func(context.Context) error, error) {
+			return &PostFilterServer{
+				PreFilterServer:           preFilterServer(deps),
+				SpicedbExperimentalClient: deps.SpicedbExperimentalClient,
+			}, nil, nil
+		},
+	},
+	{
+		name: "materialized",
+		description: "Joins the package list to a Postgres table of the hosts each user can read, built from " +
+			"SpiceDB and kept fresh from the Watch API in the background. Other endpoints are answered like in " +
+			"pre-filter.",
+		requires: []experiment.Dependency{experiment.SpiceDB, experiment.Postgres},
+		setup: func(ctx context.Context, deps experiment.Dependencies) (api.StrictServerInterface, func(context.Context) error, error) {
+			access, err := newAccessTable(deps.SpicedbClient)
+			if err != nil {
+				return nil, nil, err
+			}
+			access.start()
+
+			teardown := func(context.Context) error {
+				return access.stop()
+			}
+			return &MaterializedServer{PreFilterServer: preFilterServer(deps), access: access}, teardown, nil
+		},
+	},
+	{
+		name: "workspace-pre-filter",
+		description: "Looks up the workspaces the user can read the hosts of with SpiceDB LookupResources and " +
+			"restricts the package list to the hosts of those inventory groups. Other endpoints are answered like " +
+			"in pre-filter.",
+		requires: []experiment.Dependency{experiment.SpiceDB},
+		newServer: func(base PreFilterServer) api.StrictServerInterface {
+			return &WorkspaceServer{PreFilterServer: base}
+		},
+	},
+	{
+		name: "streaming-pre-filter",
+		description: "Copies the hosts of the SpiceDB LookupResources stream into a temporary table with COPY FROM " +
+			"STDIN while the stream is received and joins the package list to it. Other endpoints are answered " +
+			"like in pre-filter.",
+		requires: []experiment.Dependency{experiment.SpiceDB},
+		newServer: func(base PreFilterServer) api.StrictServerInterface {
+			return &StreamingServer{PreFilterServer: base}
+		},
+	},
+	{
+		name: "patch-system-pre-filter",
+		description: "Looks up the patch systems the user can read with SpiceDB LookupResources, applying the patch " +
+			"permission on top of host read, and restricts the package list to them without joining " +
+			"inventory.hosts. Other endpoints are answered like in pre-filter.",
+		requires: []experiment.Dependency{experiment.SpiceDB},
+		newServer: func(base PreFilterServer) api.StrictServerInterface {
+			return &PatchSystemServer{PreFilterServer: base}
+		},
+	},
+	{
+		name: "patch-pre-filter",
+		description: "Looks up the patch packages the user can read with SpiceDB LookupResources and counts the " +
+			"systems of the account for those packages only. Other endpoints are answered like in pre-filter.",
+		requires: []experiment.Dependency{experiment.SpiceDB},
+		newServer: func(base PreFilterServer) api.StrictServerInterface {
+			return &PatchPackageServer{PreFilterServer: base}
+		},
+	},
+}
+
+func init() {
+	for _, e := range experiments {
+		experiment.Register(e)
+	}
+}
+
+// Handler serves the strict server of an experiment, answering errors with problem documents
+func Handler(ssi api.StrictServerInterface) http.Handler {
+	return api.HandlerWithOptions(api.NewStrictHandlerWithOptions(ssi, nil, strictServerOptions), chiServerOptions)
+}
+
+// serverExperiment is an experiment answered by one of the servers of this package. Experiments which only need
+// the shared clients build their server with newServer, the others set it up with setup, which may return a
+// teardown releasing what it acquired.
+type serverExperiment struct {
+	name        string
+	description string
+	requires    []experiment.Dependency
+	newServer   func(base PreFilterServer) api.StrictServerInterface
+	setup       func(ctx context.Context, deps experiment.Dependencies) (api.StrictServerInterface, func(context.Context) error, error)
+
+	srv      api.StrictServerInterface
+	teardown func(context.Context) error
+}
+
+// preFilterServer is the pre-filter server the other servers embed to answer the endpoints they do not change
+func preFilterServer(deps experiment.Dependencies) PreFilterServer {
+	return PreFilterServer{
+		Tracer:        deps.Tracer,
+		SpicedbClient: deps.SpicedbClient,
+		PostgresConn:  deps.PostgresConn,
+	}
+}
+
+func (e *serverExperiment) Name() string {
+	return e.name
+}
+
+func (e *serverExperiment) Description() string {
+	return e.description
+}
+
+func (e *serverExperiment) Requires() []experiment.Dependency {
+	return e.requires
+}
+
+func (e *serverExperiment) Setup(ctx context.Context, deps experiment.Dependencies) error {
+	if e.setup == nil {
+		e.srv = e.newServer(preFilterServer(deps))
+		return nil
+	}
+
+	srv, teardown, err := e.setup(ctx, deps)
+	if err != nil {
+		return err
+	}
+	e.srv, e.teardown = srv, teardown
+	return nil
+}
+
+func (e *serverExperiment) Teardown(ctx context.Context) error {
+	if e.teardown == nil {
+		return nil
+	}
+	return e.teardown(ctx)
+}
+
+func (e *serverExperiment) Server() api.StrictServerInterface {
+	return e.srv
+}
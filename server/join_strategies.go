@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/merlante/inventory-access-poc/problem"
+)
+
+// QueryOptimizationHeader selects the join strategy of the pre-filter experiment
+const QueryOptimizationHeader = "Query-Optimization"
+
+const defaultJoinStrategy = "in-list"
+
+type queryOptimizationContextKey struct{}
+
+// joinStrategy reads a page of the package list of the account restricted to the hosts the user can read in SpiceDB
+type joinStrategy func(ctx context.Context, c *PreFilterServer, page *packagePage, accID int64, user string, opts packageListOptions) error
+
+// hostIDsJoin is a join strategy which looks up all the host ids first and then joins them to the package aggregate
+func hostIDsJoin(join func(page *packagePage, accID int64, hostIDs []string, opts packageListOptions) error) joinStrategy {
+	return func(ctx context.Context, c *PreFilterServer, page *packagePage, accID int64, user string, opts packageListOptions) error {
+		hostIDs, err := c.lookupHostIDs(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+		defer pgSpan.End()
+
+		return join(page, accID, hostIDs, opts)
+	}
+}
+
+// the ways of joining the host ids to the package aggregate which can be benchmarked against each other
+var joinStrategies = map[string]joinStrategy{
+	// host ids passed as an IN list
+	"in-list": hostIDsJoin(packagesByHostIDs),
+	// filtered system packages in a CTE which is aggregated
+	"cte": hostIDsJoin(packagesByHostIDsCTE),
+	// host ids inserted into a temporary table which is joined
+	"temp-table": hostIDsJoin(packagesByHostIDsTempTable),
+	// host ids unnested from an array in a CTE which is joined
+	"cte-instead-of-temp-table": hostIDsJoin(packagesByHostCTEinsteadOfTempTable),
+	// the IN list join without counting the systems
+	"no-counts": hostIDsJoin(packagesByHostIDsNoCounts),
+	// host ids looked up in chunks, each aggregated on its own and merged
+	"chunked": packagesByHostChunks,
+}
+
+// JoinStrategies gives the names accepted in the Query-Optimization header
+func JoinStrategies() []string {
+	names := make([]string, 0, len(joinStrategies))
+	for name := range joinStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// QueryOptimizationMiddleware puts the join strategy named in the Query-Optimization header and the chunk size of the
+// Lookup-Chunk-Size header into the request context
+func QueryOptimizationMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if name := r.Header.Get(QueryOptimizationHeader); name != "" {
+			if _, found := joinStrategies[name]; !found {
+				detail := fmt.Sprintf("unknown %s %s, must be one of %s", QueryOptimizationHeader, name, strings.Join(JoinStrategies(), ", "))
+				problem.New(http.StatusBadRequest, detail).WithType(problem.TypeValidation).Write(w)
+				return
+			}
+			ctx = context.WithValue(ctx, queryOptimizationContextKey{}, name)
+		}
+
+		if value := r.Header.Get(LookupChunkSizeHeader); value != "" {
+			size, err := strconv.Atoi(value)
+			if err != nil || size < 1 || size > maxLookupChunkSize {
+				detail := fmt.Sprintf("invalid %s %s, must be between 1 and %d", LookupChunkSizeHeader, value, maxLookupChunkSize)
+				problem.New(http.StatusBadRequest, detail).WithType(problem.TypeValidation).Write(w)
+				return
+			}
+			ctx = context.WithValue(ctx, lookupChunkSizeContextKey{}, size)
+		}
+
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// extractQueryOptimization gives the join strategy selected for the request and its name
+func extractQueryOptimization(ctx context.Context) (string, joinStrategy) {
+	name, ok := ctx.Value(queryOptimizationContextKey{}).(string)
+	if !ok {
+		name = defaultJoinStrategy
+	}
+
+	return name, joinStrategies[name]
+}
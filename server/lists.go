@@ -0,0 +1,139 @@
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	listPageSize    = 20
+	maxListPageSize = 100
+)
+
+// listOptions are the validated paging, sort and filter parameters of an offset paged list request
+type listOptions struct {
+	Page       int
+	Limit      int
+	SortKey    string
+	SortColumn string
+	Ascending  bool
+	// Filter holds the filters in effect as they are reported in meta and repeated in links
+	Filter        map[string]string
+	SystemProfile []systemProfileFilter
+}
+
+// getListOptions validates the paging and sort parameters common to the lists, sortColumns maps every accepted
+// sort_key to the column it sorts by
+func getListOptions(page, limit *int, sortKey *string, sortOrder *bool, sortColumns map[string]string, defaultSortKey string) (listOptions, error) {
+	opts := listOptions{
+		Page:      1,
+		Limit:     listPageSize,
+		SortKey:   defaultSortKey,
+		Ascending: true,
+		Filter:    map[string]string{},
+	}
+
+	if page != nil {
+		if *page < 1 {
+			return opts, errors.Errorf("invalid page %d, pages are numbered from 1", *page)
+		}
+		opts.Page = *page
+	}
+
+	if limit != nil {
+		if *limit < 1 || *limit > maxListPageSize {
+			return opts, errors.Errorf("invalid limit %d, must be between 1 and %d", *limit, maxListPageSize)
+		}
+		opts.Limit = *limit
+	}
+
+	if sortKey != nil {
+		if _, ok := sortColumns[*sortKey]; !ok {
+			return opts, errors.Errorf("invalid sort_key %q", *sortKey)
+		}
+		opts.SortKey = *sortKey
+	}
+	opts.SortColumn = sortColumns[opts.SortKey]
+
+	if sortOrder != nil {
+		opts.Ascending = *sortOrder
+	}
+
+	return opts, nil
+}
+
+// setSystemProfile restricts the list to the hosts matching the system profile filters
+func (o *listOptions) setSystemProfile(filters []systemProfileFilter) {
+	o.SystemProfile = filters
+	for _, f := range filters {
+		o.Filter[f.Param] = f.Value
+	}
+}
+
+// fetchPage counts the rows of q and reads the requested page of them with the given select list,
+// idColumn breaks ties in the sort order
+func (o listOptions) fetchPage(q *gorm.DB, columns string, idColumn string, total *int64, dest interface{}) error {
+	q = q.Session(&gorm.Session{})
+	if err := q.Count(total).Error; err != nil {
+		return err
+	}
+
+	direction := "ASC"
+	if !o.Ascending {
+		direction = "DESC"
+	}
+
+	return q.Select(columns).
+		Order(fmt.Sprintf("%s %s, %s %s", o.SortColumn, direction, idColumn, direction)).
+		Offset((o.Page - 1) * o.Limit).
+		Limit(o.Limit).
+		Find(dest).Error
+}
+
+// query returns the query string reproducing the sort, filter and limit options, without the page
+func (o listOptions) query() url.Values {
+	q := url.Values{}
+	q.Set("limit", strconv.Itoa(o.Limit))
+	q.Set("sort_key", o.SortKey)
+	q.Set("sort_order", strconv.FormatBool(o.Ascending))
+	for k, v := range o.Filter {
+		q.Set(k, v)
+	}
+	return q
+}
+
+func (o listOptions) meta(total int64) ListMeta {
+	sort := o.SortKey
+	if !o.Ascending {
+		sort = "-" + sort
+	}
+
+	return ListMeta{
+		TotalItems: total,
+		Limit:      o.Limit,
+		Sort:       sort,
+		Filter:     o.Filter,
+	}
+}
+
+func (o listOptions) links(path string, total int64) ListLinks {
+	pageLink := func(page int) string {
+		q := o.query()
+		q.Set("page", strconv.Itoa(page))
+		return path + "?" + q.Encode()
+	}
+
+	links := ListLinks{First: pageLink(1)}
+	if int64(o.Page*o.Limit) < total {
+		links.Next = pageLink(o.Page + 1)
+	}
+	if o.Page > 1 {
+		links.Previous = pageLink(o.Page - 1)
+	}
+
+	return links
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// MaterializedServer joins the package list to the user_host_access table kept by an accessTable instead of
+// asking SpiceDB during the request. The other endpoints are answered like in the pre-filter experiment.
+type MaterializedServer struct {
+	PreFilterServer
+	access *accessTable
+}
+
+func (c *MaterializedServer) GetContentPackages(ctx context.Context, request api.GetContentPackagesRequestObject) (api.GetContentPackagesResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentPackages")
+	defer span.End()
+
+	opts, err := getPackageListOptions(ctx, request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	revision, appliedAt, synced := c.access.state()
+	if !synced {
+		return nil, spicedbError(errors.New("access table is not built yet"))
+	}
+	span.SetAttributes(attribute.String("access.revision", revision))
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+
+	page := packagePage{}
+	err = cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		q := packageAggregate(tx, accountId, opts).
+			Joins("JOIN user_host_access uha ON ih.id = uha.host_id").
+			Where("uha.user_id = ?", user)
+
+		return fetchPackagePage(tx, q, opts, &page)
+	})
+	if err != nil {
+		return nil, databaseError(err, "failed to get counts")
+	}
+
+	packages, err := GetPackagesPayload(page, opts)
+	packages.Meta.AccessRevision = revision
+	packages.Meta.AccessAppliedAt = &appliedAt
+
+	pgSpan.End()
+
+	return packages, err
+}
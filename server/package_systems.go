@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"gorm.io/gorm"
+)
+
+var packageSystemSortColumns = map[string]string{
+	"display_name":   "sp.display_name",
+	"installed_evra": `p.evra COLLATE "numeric"`,
+}
+
+// PackageSystemItem is a system with the package installed
+type PackageSystemItem struct {
+	InventoryID   string `gorm:"column:inventory_id" json:"inventory_id"`
+	DisplayName   string `gorm:"column:display_name" json:"display_name"`
+	InstalledEVRA string `gorm:"column:installed_evra" json:"installed_evra"`
+	// newest update available for the installed package, null when it is up to date
+	LatestEVRA *string `gorm:"column:latest_evra" json:"latest_evra"`
+	// None, Installable or Applicable
+	UpdateStatus string `gorm:"column:update_status" json:"update_status"`
+}
+
+type PackageSystemsPayload struct {
+	Data  []PackageSystemItem `json:"data"`
+	Meta  ListMeta            `json:"meta"`
+	Links ListLinks           `json:"links"`
+}
+
+func (p PackageSystemsPayload) VisitGetContentPackagesPackageNameSystemsResponse(w http.ResponseWriter) error {
+	return writeJSON(w, p)
+}
+
+type packageSystemsOptions struct {
+	listOptions
+	PackageName      string
+	PatchesAvailable *bool
+}
+
+func getPackageSystemsOptions(request api.GetContentPackagesPackageNameSystemsRequestObject) (packageSystemsOptions, error) {
+	params := request.Params
+	list, err := getListOptions(params.Page, params.Limit, params.SortKey, params.SortOrder, packageSystemSortColumns, "display_name")
+	if err != nil {
+		return packageSystemsOptions{}, err
+	}
+
+	opts := packageSystemsOptions{
+		listOptions:      list,
+		PackageName:      request.PackageName,
+		PatchesAvailable: params.PatchesAvailable,
+	}
+	if opts.PatchesAvailable != nil {
+		opts.Filter["patches_available"] = strconv.FormatBool(*opts.PatchesAvailable)
+	}
+
+	return opts, nil
+}
+
+func (o packageSystemsOptions) path() string {
+	return fmt.Sprintf("%s/%s/systems", packagesPath, url.PathEscape(o.PackageName))
+}
+
+func (c *PreFilterServer) GetContentPackagesPackageNameSystems(ctx context.Context, request api.GetContentPackagesPackageNameSystemsRequestObject) (api.GetContentPackagesPackageNameSystemsResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentPackagesPackageNameSystems")
+	defer span.End()
+
+	opts, err := getPackageSystemsOptions(request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+	opts.setSystemProfile(systemProfileFilters(ctx))
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	hostIDs, err := c.lookupHostIDs(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return packageSystems(accountId, hostIDsFilter(hostIDs), opts)
+}
+
+func (c *BaselineServer) GetContentPackagesPackageNameSystems(ctx context.Context, request api.GetContentPackagesPackageNameSystemsRequestObject) (api.GetContentPackagesPackageNameSystemsResponseObject, error) {
+	opts, err := getPackageSystemsOptions(request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+	opts.setSystemProfile(systemProfileFilters(ctx))
+
+	_, accountId, _ := getIdentityFromContext(ctx)
+
+	ctx, span := c.Tracer.Start(ctx, "GetContentPackagesPackageNameSystems")
+	defer span.End()
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return packageSystems(accountId, ungroupedHostsFilter, opts)
+}
+
+// packageSystems lists the systems of the account passing the host filter that have the package installed.
+// A package nobody has installed gives an empty list.
+func packageSystems(accID int64, filter hostFilter, opts packageSystemsOptions) (PackageSystemsPayload, error) {
+	var total int64
+	items := make([]PackageSystemItem, 0, opts.Limit)
+
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		q := tx.Table("system_platform sp").
+			Joins("JOIN system_package spkg ON sp.id = spkg.system_id AND sp.rh_account_id = spkg.rh_account_id").
+			Joins("JOIN package_name pn ON spkg.name_id = pn.id").
+			Joins("JOIN package p ON spkg.package_id = p.id").
+			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
+			Where("sp.rh_account_id = ?", accID).
+			Where("pn.name = ?", opts.PackageName)
+		q = filterSystemProfile(filter(q), opts.SystemProfile)
+
+		if opts.PatchesAvailable != nil {
+			if *opts.PatchesAvailable {
+				q = q.Where("update_status(spkg.update_data) != 'None'")
+			} else {
+				q = q.Where("update_status(spkg.update_data) = 'None'")
+			}
+		}
+
+		return opts.fetchPage(q, `
+			sp.inventory_id inventory_id,
+			sp.display_name display_name,
+			p.evra installed_evra,
+			spkg.latest_evra latest_evra,
+			update_status(spkg.update_data) update_status
+		`, "sp.id", &total, &items)
+	})
+	if err != nil {
+		return PackageSystemsPayload{}, databaseError(err, "failed to get package systems")
+	}
+
+	return PackageSystemsPayload{
+		Data:  items,
+		Meta:  opts.meta(total),
+		Links: opts.links(opts.path(), total),
+	}, nil
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"gorm.io/gorm"
+)
+
+// sort_key values accepted by /content/packages/{package_name}/versions mapped to the columns of the per-version aggregate
+var packageVersionSortColumns = map[string]string{
+	"evra":                `versions.evra COLLATE "numeric"`,
+	"systems":             "versions.systems",
+	"systems_installable": "versions.systems_installable",
+}
+
+// PackageVersionItem is an installed version of a package
+type PackageVersionItem struct {
+	EVRA    string `gorm:"column:evra" json:"evra"`
+	Systems int    `gorm:"column:systems" json:"systems"`
+	// systems on the version which have an installable update of the package
+	SystemsInstallable int `gorm:"column:systems_installable" json:"systems_installable"`
+}
+
+type PackageVersionsPayload struct {
+	Data  []PackageVersionItem `json:"data"`
+	Meta  ListMeta             `json:"meta"`
+	Links ListLinks            `json:"links"`
+}
+
+func (p PackageVersionsPayload) VisitGetContentPackagesPackageNameVersionsResponse(w http.ResponseWriter) error {
+	return writeJSON(w, p)
+}
+
+type packageVersionsOptions struct {
+	listOptions
+	PackageName string
+}
+
+func getPackageVersionsOptions(request api.GetContentPackagesPackageNameVersionsRequestObject) (packageVersionsOptions, error) {
+	params := request.Params
+	list, err := getListOptions(params.Page, params.Limit, params.SortKey, params.SortOrder, packageVersionSortColumns, "evra")
+	if err != nil {
+		return packageVersionsOptions{}, err
+	}
+
+	return packageVersionsOptions{listOptions: list, PackageName: request.PackageName}, nil
+}
+
+func (o packageVersionsOptions) path() string {
+	return fmt.Sprintf("%s/%s/versions", packagesPath, url.PathEscape(o.PackageName))
+}
+
+func (c *PreFilterServer) GetContentPackagesPackageNameVersions(ctx context.Context, request api.GetContentPackagesPackageNameVersionsRequestObject) (api.GetContentPackagesPackageNameVersionsResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentPackagesPackageNameVersions")
+	defer span.End()
+
+	opts, err := getPackageVersionsOptions(request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	hostIDs, err := c.lookupHostIDs(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return packageVersions(accountId, hostIDsFilter(hostIDs), opts)
+}
+
+func (c *BaselineServer) GetContentPackagesPackageNameVersions(ctx context.Context, request api.GetContentPackagesPackageNameVersionsRequestObject) (api.GetContentPackagesPackageNameVersionsResponseObject, error) {
+	opts, err := getPackageVersionsOptions(request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	_, accountId, _ := getIdentityFromContext(ctx)
+
+	ctx, span := c.Tracer.Start(ctx, "GetContentPackagesPackageNameVersions")
+	defer span.End()
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return packageVersions(accountId, ungroupedHostsFilter, opts)
+}
+
+// packageVersions counts the systems of the account passing the host filter per installed version of the package
+func packageVersions(accID int64, filter hostFilter, opts packageVersionsOptions) (PackageVersionsPayload, error) {
+	var total int64
+	items := make([]PackageVersionItem, 0, opts.Limit)
+
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		q := tx.Table("system_platform sp").
+			Select(`
+				p.evra evra,
+				count(*) as systems,
+				count(*) filter (where update_status(spkg.update_data) = 'Installable') as systems_installable
+			`).
+			Joins("JOIN system_package spkg ON sp.id = spkg.system_id AND sp.rh_account_id = spkg.rh_account_id").
+			Joins("JOIN package_name pn ON spkg.name_id = pn.id").
+			Joins("JOIN package p ON spkg.package_id = p.id").
+			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
+			Where("sp.rh_account_id = ?", accID).
+			Where("pn.name = ?", opts.PackageName)
+		q = filter(q).Group("p.evra")
+
+		return opts.fetchPage(tx.Table("(?) AS versions", q), "versions.*", "versions.evra", &total, &items)
+	})
+	if err != nil {
+		return PackageVersionsPayload{}, databaseError(err, "failed to get package versions")
+	}
+
+	return PackageVersionsPayload{
+		Data:  items,
+		Meta:  opts.meta(total),
+		Links: opts.links(opts.path(), total),
+	}, nil
+}
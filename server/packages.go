@@ -0,0 +1,454 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	packagesPath        = "/content/packages"
+	packagesPageSize    = 20
+	maxPackagesPageSize = 100
+)
+
+// sort_key values accepted by /content/packages mapped to the columns of the per-package aggregate
+var packageSortColumns = map[string]string{
+	"name":                "pkgs.name",
+	"systems_installed":   "pkgs.systems_installed",
+	"systems_installable": "pkgs.systems_installable",
+	"systems_applicable":  "pkgs.systems_applicable",
+}
+
+// cursorKey signs the cursors of the package list. It is random unless SetCursorKey is called, cursors are then
+// only accepted by the instance which issued them.
+var cursorKey = func() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+// SetCursorKey sets the key the package list cursors are signed with, instances sharing it accept each other's cursors
+func SetCursorKey(key []byte) {
+	cursorKey = key
+}
+
+// newestPackageJoin joins the newest package of the package_name pn as lp, its summary and description
+// describe the package name
+const newestPackageJoin = `LEFT JOIN LATERAL (
+	SELECT p.summary_hash, p.description_hash
+	FROM package p
+	WHERE p.name_id = pn.id
+	ORDER BY p.evra COLLATE "numeric" DESC
+	LIMIT 1
+) lp ON true`
+
+// PackageItem is a row of the package list
+type PackageItem struct {
+	cachecontent.PackageAccountData
+	Name        string  `gorm:"column:name" json:"name"`
+	Summary     *string `gorm:"column:summary" json:"summary"`
+	Description *string `gorm:"column:description" json:"description"`
+	// newest update available for the package on any of the systems counted
+	LatestEVRA *string `gorm:"column:latest_evra" json:"latest_evra"`
+}
+
+// packagePage is one page of the package list together with what is needed to build its meta and links
+type packagePage struct {
+	Items   []PackageItem
+	Total   int64
+	HasMore bool
+}
+
+// packageListOptions are the validated filter, sort and paging parameters of a package list request.
+// They are applied in the same way by every experiment so that only the access part of the query differs.
+type packageListOptions struct {
+	Page             int
+	Limit            int
+	Cursor           *packageCursor
+	Matches          string
+	PatchesAvailable *bool
+	Tags             []string
+	HostTags         []hostTag
+	SystemProfile    []systemProfileFilter
+	SortKey          string
+	Ascending        bool
+	// Filter is a digest of the filters and of the identity the list is read for, a cursor is only accepted with
+	// the filter it was issued for
+	Filter string
+}
+
+// packageCursor is the keyset position of a row in the package list. It is handed out base64 encoded and signed,
+// clients should treat it as opaque.
+type packageCursor struct {
+	SortKey   string          `json:"sort_key"`
+	Ascending bool            `json:"ascending"`
+	Filter    string          `json:"filter"`
+	Value     json.RawMessage `json:"value"`
+	ID        int64           `json:"id"`
+	// Backward cursors page towards the start of the list
+	Backward bool `json:"backward,omitempty"`
+	// total of the page the cursor was issued on, reported again instead of counting the list on every page
+	Total *int64 `json:"total,omitempty"`
+}
+
+// getPackageListOptions validates the parameters of a package list request, the system profile filters are taken
+// from the context
+func getPackageListOptions(ctx context.Context, params api.GetContentPackagesParams) (packageListOptions, error) {
+	opts := packageListOptions{
+		Page:      1,
+		Limit:     packagesPageSize,
+		SortKey:   "name",
+		Ascending: true,
+	}
+
+	if params.Page != nil {
+		if *params.Page < 1 {
+			return opts, errors.Errorf("invalid page %d, pages are numbered from 1", *params.Page)
+		}
+		opts.Page = *params.Page
+	}
+
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > maxPackagesPageSize {
+			return opts, errors.Errorf("invalid limit %d, must be between 1 and %d", *params.Limit, maxPackagesPageSize)
+		}
+		opts.Limit = *params.Limit
+	}
+
+	if params.Matches != nil {
+		opts.Matches = *params.Matches
+	}
+
+	opts.PatchesAvailable = params.PatchesAvailable
+
+	hostTags, err := parseHostTags(params.Tag)
+	if err != nil {
+		return opts, err
+	}
+	if len(hostTags) > 0 {
+		opts.Tags = *params.Tag
+		opts.HostTags = hostTags
+	}
+
+	if params.SortKey != nil {
+		if _, ok := packageSortColumns[*params.SortKey]; !ok {
+			return opts, errors.Errorf("invalid sort_key %q", *params.SortKey)
+		}
+		opts.SortKey = *params.SortKey
+	}
+
+	if params.SortOrder != nil {
+		opts.Ascending = *params.SortOrder
+	}
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+	opts.Filter = opts.filterDigest(user, accountId)
+
+	if params.Cursor != nil {
+		if params.Page != nil {
+			return opts, errors.New("page and cursor cannot be combined")
+		}
+
+		cursor, err := decodePackageCursor(*params.Cursor)
+		if err != nil {
+			return opts, err
+		}
+		if cursor.SortKey != opts.SortKey || cursor.Ascending != opts.Ascending {
+			return opts, errors.New("cursor was issued for a different sort_key or sort_order")
+		}
+		if cursor.Filter != opts.Filter {
+			return opts, errors.New("cursor was issued for different filters")
+		}
+		opts.Cursor = cursor
+	}
+
+	return opts, nil
+}
+
+func decodePackageCursor(s string) (*packageCursor, error) {
+	encoded, encodedMAC, found := strings.Cut(s, ".")
+	if !found {
+		return nil, errors.New("invalid cursor")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+	if !hmac.Equal(mac, signCursor(data)) {
+		return nil, errors.New("invalid cursor")
+	}
+
+	cursor := packageCursor{}
+	if err = json.Unmarshal(data, &cursor); err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+
+	if _, ok := packageSortColumns[cursor.SortKey]; !ok {
+		return nil, errors.New("invalid cursor")
+	}
+	if _, err = cursor.sortValue(); err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+
+	return &cursor, nil
+}
+
+func (c *packageCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data) + "." + base64.RawURLEncoding.EncodeToString(signCursor(data))
+}
+
+func signCursor(data []byte) []byte {
+	mac := hmac.New(sha256.New, cursorKey)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// sortValue returns the value of the sort column at the cursor, typed for use as a query parameter
+func (c *packageCursor) sortValue() (interface{}, error) {
+	if c.SortKey == "name" {
+		var name string
+		err := json.Unmarshal(c.Value, &name)
+		return name, err
+	}
+
+	var count int64
+	err := json.Unmarshal(c.Value, &count)
+	return count, err
+}
+
+func (o packageListOptions) cursorAt(item PackageItem, backward bool, total int64) string {
+	var value interface{}
+	switch o.SortKey {
+	case "name":
+		value = item.Name
+	case "systems_installed":
+		value = item.SysInstalled
+	case "systems_installable":
+		value = item.SysInstallable
+	case "systems_applicable":
+		value = item.SysApplicable
+	}
+	data, _ := json.Marshal(value)
+
+	cursor := packageCursor{
+		SortKey:   o.SortKey,
+		Ascending: o.Ascending,
+		Filter:    o.Filter,
+		Value:     data,
+		ID:        item.PkgNameID,
+		Backward:  backward,
+		Total:     &total,
+	}
+	return cursor.encode()
+}
+
+// filterPackages wraps a per-package aggregate query and applies the filter options on top of it.
+// The aggregate has to provide the package_name_id, name, latest_evra and systems_* count columns.
+func filterPackages(tx *gorm.DB, aggregate *gorm.DB, opts packageListOptions) *gorm.DB {
+	q := tx.Table("(?) AS pkgs", aggregate)
+
+	if opts.Matches != "" {
+		q = q.Where("pkgs.name ILIKE ?", "%"+escapeLike(opts.Matches)+"%")
+	}
+
+	if opts.PatchesAvailable != nil {
+		if *opts.PatchesAvailable {
+			q = q.Where("pkgs.systems_applicable > 0")
+		} else {
+			q = q.Where("pkgs.systems_applicable = 0")
+		}
+	}
+
+	return q
+}
+
+// fetchPackagePage counts the packages matching the filter options and reads the requested page of them.
+// Cursor requests seek past the cursor row instead of using an offset and take the total from the cursor
+// instead of counting again, so that every page after the first costs the same.
+func fetchPackagePage(tx *gorm.DB, aggregate *gorm.DB, opts packageListOptions, page *packagePage) error {
+	if opts.Cursor != nil && opts.Cursor.Total != nil {
+		page.Total = *opts.Cursor.Total
+	} else if err := filterPackages(tx, aggregate, opts).Count(&page.Total).Error; err != nil {
+		return err
+	}
+
+	sortColumn := packageSortColumns[opts.SortKey]
+	ascending := opts.Ascending
+	q := filterPackages(tx, aggregate, opts)
+
+	if opts.Cursor != nil {
+		// a backward page is read in reverse order starting at the cursor and flipped afterwards
+		if opts.Cursor.Backward {
+			ascending = !ascending
+		}
+
+		value, err := opts.Cursor.sortValue()
+		if err != nil {
+			return err
+		}
+
+		comparison := ">"
+		if !ascending {
+			comparison = "<"
+		}
+		q = q.Where(fmt.Sprintf("(%s, pkgs.package_name_id) %s (?, ?)", sortColumn, comparison), value, opts.Cursor.ID)
+	} else {
+		q = q.Offset((opts.Page - 1) * opts.Limit)
+	}
+
+	direction := "ASC"
+	if !ascending {
+		direction = "DESC"
+	}
+	order := fmt.Sprintf("%s %s, pkgs.package_name_id %s", sortColumn, direction, direction)
+
+	// one extra row tells whether there is another page in the read direction
+	q = q.Order(order).Limit(opts.Limit + 1)
+
+	// package details are only looked up for the rows of the page, summary and description come from the
+	// newest package of the name, the summary cached on package_name is used when it has no summary string
+	err := tx.Table("(?) AS pkgs", q).
+		Select(`
+			pkgs.*,
+			COALESCE(ss.value, pn.summary) summary,
+			sd.value description
+		`).
+		Joins("JOIN package_name pn ON pkgs.package_name_id = pn.id").
+		Joins(newestPackageJoin).
+		Joins("LEFT JOIN strings ss ON lp.summary_hash = ss.id").
+		Joins("LEFT JOIN strings sd ON lp.description_hash = sd.id").
+		Order(order).
+		Find(&page.Items).Error
+	if err != nil {
+		return err
+	}
+
+	trimPage(page, opts)
+	return nil
+}
+
+// trimPage drops the extra row read to tell whether there is another page in the read direction and puts the rows
+// of a backward page back in list order
+func trimPage(page *packagePage, opts packageListOptions) {
+	if len(page.Items) > opts.Limit {
+		page.HasMore = true
+		page.Items = page.Items[:opts.Limit]
+	}
+
+	if opts.Cursor != nil && opts.Cursor.Backward {
+		for i, j := 0, len(page.Items)-1; i < j; i, j = i+1, j-1 {
+			page.Items[i], page.Items[j] = page.Items[j], page.Items[i]
+		}
+	}
+}
+
+// query returns the query string reproducing the sort, filter and limit options, without any paging position
+func (o packageListOptions) query() url.Values {
+	q := url.Values{}
+	q.Set("limit", strconv.Itoa(o.Limit))
+	q.Set("sort_key", o.SortKey)
+	q.Set("sort_order", strconv.FormatBool(o.Ascending))
+	if o.Matches != "" {
+		q.Set("matches", o.Matches)
+	}
+	if o.PatchesAvailable != nil {
+		q.Set("patches_available", strconv.FormatBool(*o.PatchesAvailable))
+	}
+	for _, tag := range o.Tags {
+		q.Add("tag", tag)
+	}
+	for _, f := range o.SystemProfile {
+		q.Add(f.Param, f.Value)
+	}
+	return q
+}
+
+// filterDigest digests the filter options and the identity, everything the rows and the total of the list depend
+// on apart from the paging position
+func (o packageListOptions) filterDigest(user string, accID int64) string {
+	q := o.query()
+	q.Del("limit")
+	q.Set("user", user)
+	q.Set("account", strconv.FormatInt(accID, 10))
+
+	sum := sha256.Sum256([]byte(q.Encode()))
+	return hex.EncodeToString(sum[:16])
+}
+
+func (o packageListOptions) meta(total int64) ListMeta {
+	sort := o.SortKey
+	if !o.Ascending {
+		sort = "-" + sort
+	}
+
+	filter := map[string]string{}
+	if o.Matches != "" {
+		filter["matches"] = o.Matches
+	}
+	if o.PatchesAvailable != nil {
+		filter["patches_available"] = strconv.FormatBool(*o.PatchesAvailable)
+	}
+	if len(o.Tags) > 0 {
+		filter["tag"] = strings.Join(o.Tags, ",")
+	}
+	for _, f := range o.SystemProfile {
+		filter[f.Param] = f.Value
+	}
+
+	return ListMeta{
+		TotalItems: total,
+		Limit:      o.Limit,
+		Sort:       sort,
+		Filter:     filter,
+	}
+}
+
+func (o packageListOptions) links(page packagePage) ListLinks {
+	links := ListLinks{First: listLink(packagesPath, o.query(), "")}
+	if len(page.Items) == 0 {
+		return links
+	}
+
+	backward := o.Cursor != nil && o.Cursor.Backward
+	hasNext := page.HasMore
+	hasPrevious := o.Cursor != nil || o.Page > 1
+	if backward {
+		hasNext = true
+		hasPrevious = page.HasMore
+	}
+
+	if hasNext {
+		links.Next = listLink(packagesPath, o.query(), o.cursorAt(page.Items[len(page.Items)-1], false, page.Total))
+	}
+	if hasPrevious {
+		links.Previous = listLink(packagesPath, o.query(), o.cursorAt(page.Items[0], true, page.Total))
+	}
+
+	return links
+}
+
+// escapes the LIKE wildcards in user input so that it is matched as a plain substring
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
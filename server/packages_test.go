@@ -0,0 +1,225 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/identity"
+)
+
+// signedCursor signs data the way packageCursor.encode does, for cursors encode cannot produce
+func signedCursor(data string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(data)) + "." +
+		base64.RawURLEncoding.EncodeToString(signCursor([]byte(data)))
+}
+
+func TestDecodePackageCursor(t *testing.T) {
+	total := int64(42)
+	valid := packageCursor{SortKey: "systems_installed", Ascending: true, Value: json.RawMessage("7"), ID: 3, Total: &total}
+
+	otherKey := cursorKey
+	SetCursorKey([]byte("another key"))
+	foreign := valid.encode()
+	SetCursorKey(otherKey)
+
+	encoded := valid.encode()
+	payload, signature, _ := strings.Cut(encoded, ".")
+
+	tests := []struct {
+		name   string
+		cursor string
+		// substring of the expected error, empty when the cursor is valid
+		err string
+	}{
+		{name: "valid", cursor: encoded},
+		{name: "not base64", cursor: "!!!." + signature, err: "invalid cursor"},
+		{name: "no signature", cursor: payload, err: "invalid cursor"},
+		{name: "signature not base64", cursor: payload + ".!!!", err: "invalid cursor"},
+		{name: "signed with another key", cursor: foreign, err: "invalid cursor"},
+		{
+			name:   "edited total",
+			cursor: base64.RawURLEncoding.EncodeToString([]byte(`{"sort_key":"systems_installed","value":7,"id":3,"total":1}`)) + "." + signature,
+			err:    "invalid cursor",
+		},
+		{name: "not json", cursor: signedCursor("not json"), err: "invalid cursor"},
+		{name: "unknown sort_key", cursor: signedCursor(`{"sort_key":"evra","value":"1","id":1}`), err: "invalid cursor"},
+		{name: "value of another type", cursor: signedCursor(`{"sort_key":"systems_installed","value":"many","id":1}`), err: "invalid cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := decodePackageCursor(tt.cursor)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected an error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(*cursor, valid) {
+				t.Fatalf("expected cursor %+v, got %+v", valid, *cursor)
+			}
+		})
+	}
+}
+
+func TestGetPackageListOptions(t *testing.T) {
+	ctx := identity.NewContext(context.Background(), identity.Identity{UserID: "user1", RhAccountID: 1})
+	otherUser := identity.NewContext(context.Background(), identity.Identity{UserID: "user2", RhAccountID: 1})
+
+	intParam := func(i int) *int { return &i }
+	stringParam := func(s string) *string { return &s }
+	boolParam := func(b bool) *bool { return &b }
+
+	// cursorFor is the next cursor of a page read with the parameters
+	cursorFor := func(params api.GetContentPackagesParams) *string {
+		opts, err := getPackageListOptions(ctx, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cursor := opts.cursorAt(PackageItem{Name: "kernel"}, false, 42)
+		return &cursor
+	}
+	nameCursor := cursorFor(api.GetContentPackagesParams{})
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		params api.GetContentPackagesParams
+		err    string
+		check  func(t *testing.T, opts packageListOptions)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, opts packageListOptions) {
+				if opts.Page != 1 || opts.Limit != packagesPageSize || opts.SortKey != "name" || !opts.Ascending || opts.Cursor != nil {
+					t.Fatalf("unexpected defaults %+v", opts)
+				}
+			},
+		},
+		{name: "page 0", params: api.GetContentPackagesParams{Page: intParam(0)}, err: "invalid page"},
+		{name: "limit too large", params: api.GetContentPackagesParams{Limit: intParam(maxPackagesPageSize + 1)}, err: "invalid limit"},
+		{name: "unknown sort_key", params: api.GetContentPackagesParams{SortKey: stringParam("evra")}, err: "invalid sort_key"},
+		{name: "invalid tag", params: api.GetContentPackagesParams{Tag: &[]string{"ns/=value"}}, err: "invalid tag"},
+		{
+			name:   "page and cursor",
+			params: api.GetContentPackagesParams{Page: intParam(2), Cursor: nameCursor},
+			err:    "cannot be combined",
+		},
+		{
+			name:   "cursor",
+			params: api.GetContentPackagesParams{Cursor: nameCursor},
+			check: func(t *testing.T, opts packageListOptions) {
+				if opts.Cursor == nil || opts.Cursor.Total == nil || *opts.Cursor.Total != 42 {
+					t.Fatalf("expected the cursor with its total, got %+v", opts.Cursor)
+				}
+			},
+		},
+		{
+			name:   "cursor with another limit",
+			params: api.GetContentPackagesParams{Cursor: nameCursor, Limit: intParam(50)},
+		},
+		{
+			name:   "cursor of another sort_key",
+			params: api.GetContentPackagesParams{Cursor: nameCursor, SortKey: stringParam("systems_installed")},
+			err:    "different sort_key or sort_order",
+		},
+		{
+			name:   "cursor of another sort_order",
+			params: api.GetContentPackagesParams{Cursor: nameCursor, SortOrder: boolParam(false)},
+			err:    "different sort_key or sort_order",
+		},
+		{
+			name:   "cursor of other matches",
+			params: api.GetContentPackagesParams{Cursor: nameCursor, Matches: stringParam("kernel")},
+			err:    "different filters",
+		},
+		{
+			name:   "cursor without its filters",
+			params: api.GetContentPackagesParams{Cursor: cursorFor(api.GetContentPackagesParams{PatchesAvailable: boolParam(true)})},
+			err:    "different filters",
+		},
+		{
+			name:   "cursor of another user",
+			ctx:    otherUser,
+			params: api.GetContentPackagesParams{Cursor: nameCursor},
+			err:    "different filters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCtx := tt.ctx
+			if testCtx == nil {
+				testCtx = ctx
+			}
+
+			opts, err := getPackageListOptions(testCtx, tt.params)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected an error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.check != nil {
+				tt.check(t, opts)
+			}
+		})
+	}
+}
+
+func TestTrimPage(t *testing.T) {
+	// items gives packages with the ids in the order given
+	items := func(ids ...int64) []PackageItem {
+		packages := make([]PackageItem, 0, len(ids))
+		for _, id := range ids {
+			item := PackageItem{}
+			item.PkgNameID = id
+			packages = append(packages, item)
+		}
+		return packages
+	}
+	backward := &packageCursor{Backward: true}
+
+	tests := []struct {
+		name    string
+		read    []PackageItem
+		cursor  *packageCursor
+		ids     []int64
+		hasMore bool
+	}{
+		{name: "last page", read: items(1, 2), ids: []int64{1, 2}},
+		{name: "full page", read: items(1, 2, 3), ids: []int64{1, 2, 3}},
+		{name: "more pages", read: items(1, 2, 3, 4), ids: []int64{1, 2, 3}, hasMore: true},
+		{name: "backward first page", read: items(2, 1), cursor: backward, ids: []int64{1, 2}},
+		{name: "backward with more pages", read: items(6, 5, 4, 3), cursor: backward, ids: []int64{4, 5, 6}, hasMore: true},
+		{name: "empty", read: items(), ids: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := packagePage{Items: tt.read}
+			trimPage(&page, packageListOptions{Limit: 3, Cursor: tt.cursor})
+
+			ids := make([]int64, 0, len(page.Items))
+			for _, item := range page.Items {
+				ids = append(ids, item.PkgNameID)
+			}
+			if !reflect.DeepEqual(ids, tt.ids) || page.HasMore != tt.hasMore {
+				t.Fatalf("expected %v with more %v, got %v with more %v", tt.ids, tt.hasMore, ids, page.HasMore)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"github.com/merlante/inventory-access-poc/problem"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// ComparePreFilterHeader makes the patch-pre-filter experiment compare its package list with the one of the host
+// pre-filter, which is read after the experiment's own list so that it does not affect its latency
+const ComparePreFilterHeader = "Compare-Pre-Filter"
+
+type comparePreFilterContextKey struct{}
+
+// PreFilterComparison compares a page of the package list with the page the host pre-filter gives for the same request
+type PreFilterComparison struct {
+	LatencyMs           float64 `json:"latency_ms"`
+	PreFilterLatencyMs  float64 `json:"pre_filter_latency_ms"`
+	PreFilterTotalItems int64   `json:"pre_filter_total_items"`
+	// packages of the pre-filter page missing from the page, and packages of the page the pre-filter does not list
+	MissingItems int `json:"missing_items"`
+	ExtraItems   int `json:"extra_items"`
+	// packages on both pages whose system counts differ
+	CountMismatches int `json:"count_mismatches"`
+}
+
+// ComparePreFilterMiddleware puts the Compare-Pre-Filter header into the request context
+func ComparePreFilterMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.Header.Get(ComparePreFilterHeader)
+		if value == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		compare, err := strconv.ParseBool(value)
+		if err != nil {
+			detail := fmt.Sprintf("invalid %s %s, must be true or false", ComparePreFilterHeader, value)
+			problem.New(http.StatusBadRequest, detail).WithType(problem.TypeValidation).Write(w)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), comparePreFilterContextKey{}, compare)
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func comparePreFilter(ctx context.Context) bool {
+	compare, _ := ctx.Value(comparePreFilterContextKey{}).(bool)
+	return compare
+}
+
+// PatchPackageServer looks up the packages the user can read as patch/patch resources and counts the systems of the
+// account for those packages only. The systems counted are not restricted to the ones the user can read. The other
+// endpoints are answered like in the pre-filter experiment.
+type PatchPackageServer struct {
+	PreFilterServer
+}
+
+func (c *PatchPackageServer) GetContentPackages(ctx context.Context, request api.GetContentPackagesRequestObject) (api.GetContentPackagesResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentPackages")
+	defer span.End()
+
+	opts, err := getPackageListOptions(ctx, request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	start := time.Now()
+
+	nameIDs, err := c.lookupPackageNameIDs(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("packages", len(nameIDs)))
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+
+	page := packagePage{}
+	err = cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		q := packageAggregate(tx, accountId, opts).
+			Where("spkg.name_id IN ?", nameIDs)
+
+		return fetchPackagePage(tx, q, opts, &page)
+	})
+	if err != nil {
+		return nil, databaseError(err, "failed to get counts")
+	}
+
+	pgSpan.End()
+	latency := time.Since(start)
+
+	packages, err := GetPackagesPayload(page, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if comparePreFilter(ctx) {
+		packages.Meta.PreFilterComparison, err = c.compareWithPreFilter(ctx, page, latency, accountId, user, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return packages, nil
+}
+
+// lookupPackageNameIDs asks SpiceDB for the package name ids of the patch packages the user can read
+func (c *PatchPackageServer) lookupPackageNameIDs(ctx context.Context, user string) ([]int64, error) {
+	_, spiceSpan := c.Tracer.Start(ctx, "SpiceDB patch package pre-filter call")
+	defer spiceSpan.End()
+
+	ids, err := lookupResourceIDs(ctx, c.SpicedbClient, &v1.LookupResourcesRequest{
+		ResourceObjectType: "patch/patch",
+		Permission:         "read",
+		Subject: &v1.SubjectReference{
+			Object: &v1.ObjectReference{
+				ObjectType: "user",
+				ObjectId:   user,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nameIDs := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		nameID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, spicedbError(errors.Wrapf(err, "invalid patch/patch id %q", id))
+		}
+		nameIDs = append(nameIDs, nameID)
+	}
+	return nameIDs, nil
+}
+
+// compareWithPreFilter reads the page of the host pre-filter with the in-list join and compares it with the page
+func (c *PatchPackageServer) compareWithPreFilter(ctx context.Context, page packagePage, latency time.Duration, accID int64, user string, opts packageListOptions) (*PreFilterComparison, error) {
+	ctx, span := c.Tracer.Start(ctx, "Pre-filter comparison")
+	defer span.End()
+
+	start := time.Now()
+
+	hostIDs, err := c.lookupHostIDs(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	preFilterPage := packagePage{}
+	if err := packagesByHostIDs(&preFilterPage, accID, hostIDs, opts); err != nil {
+		return nil, err
+	}
+
+	comparison := PreFilterComparison{
+		LatencyMs:           float64(latency.Microseconds()) / 1000,
+		PreFilterLatencyMs:  float64(time.Since(start).Microseconds()) / 1000,
+		PreFilterTotalItems: preFilterPage.Total,
+	}
+
+	expected := map[int64]PackageItem{}
+	for _, item := range preFilterPage.Items {
+		expected[item.PkgNameID] = item
+	}
+	for _, item := range page.Items {
+		want, found := expected[item.PkgNameID]
+		if !found {
+			comparison.ExtraItems++
+			continue
+		}
+		delete(expected, item.PkgNameID)
+
+		if item.SysInstalled != want.SysInstalled || item.SysInstallable != want.SysInstallable || item.SysApplicable != want.SysApplicable {
+			comparison.CountMismatches++
+		}
+	}
+	comparison.MissingItems = len(expected)
+
+	span.SetAttributes(
+		attribute.Int("comparison.missing_items", comparison.MissingItems),
+		attribute.Int("comparison.extra_items", comparison.ExtraItems),
+		attribute.Int("comparison.count_mismatches", comparison.CountMismatches),
+	)
+
+	return &comparison, nil
+}
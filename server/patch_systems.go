@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"strconv"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// PatchSystemServer looks up the patch systems the user can read, which also requires the patch_system_read
+// permission on their host, and restricts the package list to them by system_platform id. The other endpoints are
+// answered like in the pre-filter experiment.
+type PatchSystemServer struct {
+	PreFilterServer
+}
+
+func (c *PatchSystemServer) GetContentPackages(ctx context.Context, request api.GetContentPackagesRequestObject) (api.GetContentPackagesResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentPackages")
+	defer span.End()
+
+	opts, err := getPackageListOptions(ctx, request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	systemIDs, err := c.lookupSystemIDs(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("systems", len(systemIDs)))
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+
+	page := packagePage{}
+	err = cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		return fetchPackagePage(tx, systemPackageAggregate(tx, accountId, systemIDs, opts), opts, &page)
+	})
+	if err != nil {
+		return nil, databaseError(err, "failed to get counts")
+	}
+
+	packages, err := GetPackagesPayload(page, opts)
+
+	pgSpan.End()
+
+	return packages, err
+}
+
+// lookupSystemIDs asks SpiceDB for the system_platform ids of the patch systems the user can read
+func (c *PatchSystemServer) lookupSystemIDs(ctx context.Context, user string) ([]int64, error) {
+	_, spiceSpan := c.Tracer.Start(ctx, "SpiceDB patch system pre-filter call")
+	defer spiceSpan.End()
+
+	ids, err := lookupResourceIDs(ctx, c.SpicedbClient, &v1.LookupResourcesRequest{
+		ResourceObjectType: "patch/system",
+		Permission:         "read",
+		Subject: &v1.SubjectReference{
+			Object: &v1.ObjectReference{
+				ObjectType: "user",
+				ObjectId:   user,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	systemIDs := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		systemID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, spicedbError(errors.Wrapf(err, "invalid patch/system id %q", id))
+		}
+		systemIDs = append(systemIDs, systemID)
+	}
+	return systemIDs, nil
+}
+
+// systemPackageAggregate counts the systems per installed package like packageAggregate, restricted to the systems
+// by their id. inventory.hosts is only joined when the tag or system profile filters need it.
+func systemPackageAggregate(tx *gorm.DB, accID int64, systemIDs []int64, opts packageListOptions) *gorm.DB {
+	q := tx.Table("system_platform sp").
+		Select(packageAggregateColumns).
+		Joins("JOIN system_package spkg ON sp.id = spkg.system_id AND sp.rh_account_id = spkg.rh_account_id").
+		Joins("JOIN package_name pn ON spkg.name_id = pn.id").
+		Where("sp.rh_account_id = ?", accID).
+		Where("sp.id IN ?", systemIDs)
+
+	if len(opts.HostTags) > 0 || len(opts.SystemProfile) > 0 {
+		q = q.Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id")
+		q = filterSystemProfile(filterHostTags(q, opts.HostTags), opts.SystemProfile)
+	}
+
+	return q.Group("sp.rh_account_id, spkg.name_id, pn.name")
+}
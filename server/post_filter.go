@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// hosts authorized by a single BulkCheckPermission call
+const bulkCheckBatchSize = 1000
+
+// PostFilterServer reads the package rows of all the systems of the account and drops the rows of the hosts
+// SpiceDB denies, the package list is aggregated from the permitted rows. The other endpoints are answered like in
+// the pre-filter experiment.
+//
+// The hosts are checked with the experimental BulkCheckPermission, the stable CheckBulkPermissions of the
+// permissions service is only in authzed-go releases after the v0.10.0 this module is built with.
+type PostFilterServer struct {
+	PreFilterServer
+	SpicedbExperimentalClient v1.ExperimentalServiceClient
+}
+
+// systemPackageRow is an installed package of a system, before authorization
+type systemPackageRow struct {
+	InventoryID   string  `gorm:"column:inventory_id"`
+	PackageNameID int64   `gorm:"column:package_name_id"`
+	LatestEVRA    *string `gorm:"column:latest_evra"`
+	UpdateStatus  string  `gorm:"column:update_status"`
+}
+
+func (c *PostFilterServer) GetContentPackages(ctx context.Context, request api.GetContentPackagesRequestObject) (api.GetContentPackagesResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentPackages")
+	defer span.End()
+
+	opts, err := getPackageListOptions(ctx, request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres rows query")
+	rows, err := systemPackageRows(accountId, opts)
+	pgSpan.End()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	hostIDs := make([]string, 0)
+	for _, row := range rows {
+		if !seen[row.InventoryID] {
+			seen[row.InventoryID] = true
+			hostIDs = append(hostIDs, row.InventoryID)
+		}
+	}
+
+	permitted, err := c.checkHosts(ctx, user, hostIDs)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("hosts.total", len(hostIDs)), attribute.Int("hosts.permitted", len(permitted)))
+
+	// the permitted rows are aggregated here, the aggregate is only sorted and paged in the database
+	permittedHosts := make(map[string]bool, len(permitted))
+	for _, id := range permitted {
+		permittedHosts[id] = true
+	}
+	merged := aggregatePackageRows(rows, permittedHosts)
+
+	page := packagePage{}
+	_, pgSpan = c.Tracer.Start(ctx, "Postgres query")
+	err = cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		return fetchPackagePage(tx, merged.aggregate(tx, accountId), opts, &page)
+	})
+	pgSpan.End()
+	if err != nil {
+		return nil, databaseError(err, "failed to get counts")
+	}
+
+	return GetPackagesPayload(page, opts)
+}
+
+// systemPackageRows reads the installed packages of all the systems of the account which pass the host filters
+func systemPackageRows(accID int64, opts packageListOptions) ([]systemPackageRow, error) {
+	var rows []systemPackageRow
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		q := tx.Table("system_platform sp").
+			Select(`
+				sp.inventory_id inventory_id,
+				spkg.name_id package_name_id,
+				spkg.latest_evra latest_evra,
+				update_status(spkg.update_data) update_status
+			`).
+			Joins("JOIN system_package spkg ON sp.id = spkg.system_id AND sp.rh_account_id = spkg.rh_account_id").
+			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
+			Where("sp.rh_account_id = ?", accID)
+		q = filterSystemProfile(filterHostTags(q, opts.HostTags), opts.SystemProfile)
+
+		return q.Find(&rows).Error
+	})
+
+	return rows, databaseError(err, "failed to get system packages")
+}
+
+// aggregatePackageRows counts the systems per package over the rows of the permitted hosts. The newest evra of each
+// package is left to the database, which knows the "numeric" collation, every distinct evra is passed on.
+func aggregatePackageRows(rows []systemPackageRow, permitted map[string]bool) mergedPackages {
+	merged := mergedPackages{items: map[int64]*PackageItem{}}
+	type packageEVRA struct {
+		id   int64
+		evra string
+	}
+	evras := map[packageEVRA]bool{}
+
+	for _, row := range rows {
+		if !permitted[row.InventoryID] {
+			continue
+		}
+
+		item, found := merged.items[row.PackageNameID]
+		if !found {
+			item = &PackageItem{}
+			item.PkgNameID = row.PackageNameID
+			merged.items[row.PackageNameID] = item
+		}
+
+		item.SysInstalled++
+		if row.UpdateStatus == "Installable" {
+			item.SysInstallable++
+		}
+		if row.UpdateStatus != "None" {
+			item.SysApplicable++
+		}
+
+		if row.LatestEVRA != nil && !evras[packageEVRA{row.PackageNameID, *row.LatestEVRA}] {
+			evras[packageEVRA{row.PackageNameID, *row.LatestEVRA}] = true
+			merged.evraIDs = append(merged.evraIDs, row.PackageNameID)
+			merged.evras = append(merged.evras, *row.LatestEVRA)
+		}
+	}
+	return merged
+}
+
+// checkHosts asks SpiceDB which of the hosts the user can read, in batches of bulkCheckBatchSize
+func (c *PostFilterServer) checkHosts(ctx context.Context, user string, ids []string) ([]string, error) {
+	ctx, spiceSpan := c.Tracer.Start(ctx, "SpiceDB post-filter call")
+	defer spiceSpan.End()
+
+	subject := &v1.SubjectReference{
+		Object: &v1.ObjectReference{
+			ObjectType: "user",
+			ObjectId:   user,
+		},
+	}
+
+	permitted := make([]string, 0, len(ids))
+	batches := 0
+	for start := 0; start < len(ids); start += bulkCheckBatchSize {
+		end := start + bulkCheckBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		items := make([]*v1.BulkCheckPermissionRequestItem, 0, end-start)
+		for _, id := range ids[start:end] {
+			items = append(items, &v1.BulkCheckPermissionRequestItem{
+				Resource: &v1.ObjectReference{
+					ObjectType: "inventory/host",
+					ObjectId:   id,
+				},
+				Permission: "read",
+				Subject:    subject,
+			})
+		}
+
+		resp, err := c.SpicedbExperimentalClient.BulkCheckPermission(ctx, &v1.BulkCheckPermissionRequest{Items: items})
+		if err != nil {
+			return nil, spicedbError(err)
+		}
+		batches++
+
+		for _, pair := range resp.GetPairs() {
+			if pair.GetError() != nil {
+				return nil, spicedbError(errors.New(pair.GetError().GetMessage()))
+			}
+			if pair.GetItem().GetPermissionship() == v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION {
+				permitted = append(permitted, pair.GetRequest().GetResource().GetObjectId())
+			}
+		}
+	}
+	spiceSpan.SetAttributes(attribute.Int("spicedb.bulk_checks", batches))
+
+	return permitted, nil
+}
@@ -0,0 +1,309 @@
+package server
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/authzed-go/v1"
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"gorm.io/gorm"
+)
+
+const reposPath = "/content/repos"
+
+// sort_key values accepted by /content/repos mapped to the columns of the per-repo aggregate
+var repoSortColumns = map[string]string{
+	"name":        "repos.name",
+	"third_party": "repos.third_party",
+	"systems":     "repos.systems",
+}
+
+// RepoItem is a row of the repository list
+type RepoItem struct {
+	ID         int64  `gorm:"column:id" json:"id"`
+	Name       string `gorm:"column:name" json:"name"`
+	ThirdParty bool   `gorm:"column:third_party" json:"third_party"`
+	Systems    int    `gorm:"column:systems" json:"systems"`
+	// whether the organization is entitled to the content of the repository
+	Entitled bool `gorm:"-" json:"entitled"`
+}
+
+type ReposPayload struct {
+	Data  []RepoItem `json:"data"`
+	Meta  ListMeta   `json:"meta"`
+	Links ListLinks  `json:"links"`
+}
+
+func (p ReposPayload) VisitGetContentReposResponse(w http.ResponseWriter) error {
+	return writeJSON(w, p)
+}
+
+type RepoSystemsPayload struct {
+	Data  []SystemItem `json:"data"`
+	Meta  ListMeta     `json:"meta"`
+	Links ListLinks    `json:"links"`
+}
+
+func (p RepoSystemsPayload) VisitGetContentReposRepoIdSystemsResponse(w http.ResponseWriter) error {
+	return writeJSON(w, p)
+}
+
+func (r notFoundResponse) VisitGetContentReposRepoIdSystemsResponse(w http.ResponseWriter) error {
+	return r.visit(w)
+}
+
+type reposOptions struct {
+	listOptions
+	Matches    string
+	ThirdParty *bool
+}
+
+func getReposOptions(params api.GetContentReposParams) (reposOptions, error) {
+	list, err := getListOptions(params.Page, params.Limit, params.SortKey, params.SortOrder, repoSortColumns, "name")
+	if err != nil {
+		return reposOptions{}, err
+	}
+
+	opts := reposOptions{
+		listOptions: list,
+		ThirdParty:  params.ThirdParty,
+	}
+	if params.Matches != nil && *params.Matches != "" {
+		opts.Matches = *params.Matches
+		opts.Filter["matches"] = opts.Matches
+	}
+	if opts.ThirdParty != nil {
+		opts.Filter["third_party"] = strconv.FormatBool(*opts.ThirdParty)
+	}
+
+	return opts, nil
+}
+
+type repoSystemsOptions struct {
+	listOptions
+	RepoID int64
+}
+
+func getRepoSystemsOptions(request api.GetContentReposRepoIdSystemsRequestObject) (repoSystemsOptions, error) {
+	params := request.Params
+	list, err := getListOptions(params.Page, params.Limit, params.SortKey, params.SortOrder, systemSortColumns, "display_name")
+	if err != nil {
+		return repoSystemsOptions{}, err
+	}
+
+	return repoSystemsOptions{listOptions: list, RepoID: request.RepoId}, nil
+}
+
+func (o repoSystemsOptions) path() string {
+	return fmt.Sprintf("%s/%d/systems", reposPath, o.RepoID)
+}
+
+func (c *PreFilterServer) GetContentRepos(ctx context.Context, request api.GetContentReposRequestObject) (api.GetContentReposResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentRepos")
+	defer span.End()
+
+	opts, err := getReposOptions(request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	hostIDs, err := c.lookupHostIDs(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	entitled, err := lookupEntitledRepos(ctx, c.SpicedbClient, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return repos(accountId, hostIDsFilter(hostIDs), entitled, opts)
+}
+
+func (c *BaselineServer) GetContentRepos(ctx context.Context, request api.GetContentReposRequestObject) (api.GetContentReposResponseObject, error) {
+	opts, err := getReposOptions(request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	_, accountId, _ := getIdentityFromContext(ctx)
+
+	// entitlements are not part of the inventory access being compared, they are looked up before metering
+	entitled, err := lookupEntitledRepos(ctx, c.SpicedbClient, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := c.Tracer.Start(ctx, "GetContentRepos")
+	defer span.End()
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return repos(accountId, ungroupedHostsFilter, entitled, opts)
+}
+
+func (c *PreFilterServer) GetContentReposRepoIdSystems(ctx context.Context, request api.GetContentReposRepoIdSystemsRequestObject) (api.GetContentReposRepoIdSystemsResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentReposRepoIdSystems")
+	defer span.End()
+
+	opts, err := getRepoSystemsOptions(request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+	opts.setSystemProfile(systemProfileFilters(ctx))
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	hostIDs, err := c.lookupHostIDs(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return repoSystems(accountId, hostIDsFilter(hostIDs), opts)
+}
+
+func (c *BaselineServer) GetContentReposRepoIdSystems(ctx context.Context, request api.GetContentReposRepoIdSystemsRequestObject) (api.GetContentReposRepoIdSystemsResponseObject, error) {
+	opts, err := getRepoSystemsOptions(request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+	opts.setSystemProfile(systemProfileFilters(ctx))
+
+	_, accountId, _ := getIdentityFromContext(ctx)
+
+	ctx, span := c.Tracer.Start(ctx, "GetContentReposRepoIdSystems")
+	defer span.End()
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return repoSystems(accountId, ungroupedHostsFilter, opts)
+}
+
+// lookupEntitledRepos asks SpiceDB for the names of the repositories the organization is a content provider of,
+// following the organization's entitlement_grant to entitlement sets and bindings
+func lookupEntitledRepos(ctx context.Context, client *authzed.Client, accID int64) (map[string]bool, error) {
+	lsClient, err := client.LookupSubjects(ctx, &v1.LookupSubjectsRequest{
+		Resource: &v1.ObjectReference{
+			ObjectType: "organization",
+			ObjectId:   strconv.FormatInt(accID, 10),
+		},
+		Permission:        "content_provider",
+		SubjectObjectType: "content/repository",
+	})
+	if err != nil {
+		return nil, spicedbError(err)
+	}
+
+	entitled := map[string]bool{}
+	for {
+		next, err := lsClient.Recv()
+		if e.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, spicedbError(err)
+		}
+
+		entitled[next.GetSubject().GetSubjectObjectId()] = true
+	}
+
+	return entitled, nil
+}
+
+// repos lists the repositories enabled on the systems of the account passing the host filter,
+// entitled holds the names of the repositories the organization is entitled to
+func repos(accID int64, filter hostFilter, entitled map[string]bool, opts reposOptions) (ReposPayload, error) {
+	var total int64
+	items := make([]RepoItem, 0, opts.Limit)
+
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		q := tx.Table("system_repo sr").
+			Select(`
+				r.id id,
+				r.name name,
+				r.third_party third_party,
+				count(*) as systems
+			`).
+			Joins("JOIN system_platform sp ON sr.system_id = sp.id AND sr.rh_account_id = sp.rh_account_id").
+			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
+			Joins("JOIN repo r ON sr.repo_id = r.id").
+			Where("sr.rh_account_id = ?", accID)
+		q = filter(q)
+
+		if opts.Matches != "" {
+			q = q.Where("r.name ILIKE ?", "%"+escapeLike(opts.Matches)+"%")
+		}
+		if opts.ThirdParty != nil {
+			q = q.Where("r.third_party = ?", *opts.ThirdParty)
+		}
+
+		q = q.Group("r.id, r.name, r.third_party")
+
+		return opts.fetchPage(tx.Table("(?) AS repos", q), "repos.*", "repos.id", &total, &items)
+	})
+	if err != nil {
+		return ReposPayload{}, databaseError(err, "failed to get repos")
+	}
+
+	for i := range items {
+		items[i].Entitled = entitled[items[i].Name]
+	}
+
+	return ReposPayload{
+		Data:  items,
+		Meta:  opts.meta(total),
+		Links: opts.links(reposPath, total),
+	}, nil
+}
+
+// repoSystems lists the systems of the account passing the host filter which have the repository enabled
+func repoSystems(accID int64, filter hostFilter, opts repoSystemsOptions) (api.GetContentReposRepoIdSystemsResponseObject, error) {
+	var repo cachecontent.Repo
+	var total int64
+	var rows []cachecontent.SystemPlatform
+
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", opts.RepoID).Limit(1).Find(&repo)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		q := tx.Table("system_platform sp").
+			Joins("JOIN system_repo sr ON sr.system_id = sp.id AND sr.rh_account_id = sp.rh_account_id").
+			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
+			Where("sp.rh_account_id = ?", accID).
+			Where("sr.repo_id = ?", opts.RepoID)
+		q = filterSystemProfile(filter(q), opts.SystemProfile)
+
+		return opts.fetchPage(q, systemColumns, "sp.id", &total, &rows)
+	})
+	if err != nil {
+		return nil, databaseError(err, "failed to get repo systems")
+	}
+
+	if repo.ID == 0 {
+		return notFoundResponse{Error: fmt.Sprintf("repo %d not found", opts.RepoID)}, nil
+	}
+
+	return RepoSystemsPayload{
+		Data:  newSystemItems(rows),
+		Meta:  opts.meta(total),
+		Links: opts.links(opts.path(), total),
+	}, nil
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/merlante/inventory-access-poc/problem"
+)
+
+func writeJSON(w http.ResponseWriter, v interface{}) error {
+	jsonResponse, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(jsonResponse)
+	return err
+}
+
+// notFoundResponse is returned for items which do not exist or which the user has no access to, the two are not
+// told apart so that the response does not leak what exists outside of the user's access
+type notFoundResponse struct {
+	Error string
+}
+
+func (r notFoundResponse) visit(w http.ResponseWriter) error {
+	return problem.Write(w, http.StatusNotFound, r.Error)
+}
+
+// forbiddenResponse is returned when SpiceDB denies the user access to an item they asked for directly
+type forbiddenResponse struct {
+	Error string
+}
+
+func (r forbiddenResponse) visit(w http.ResponseWriter) error {
+	return problem.Write(w, http.StatusForbidden, r.Error)
+}
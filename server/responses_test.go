@@ -0,0 +1,306 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/merlante/inventory-access-poc/experiment"
+	"github.com/pkg/errors"
+)
+
+// responseCase is a response of an operation of the api spec, built from a payload of the given type
+type responseCase struct {
+	path    string
+	payload interface{}
+	visit   func(payload interface{}, w http.ResponseWriter) error
+}
+
+var responseCases = []responseCase{
+	{"/content/packages", PackagesPayload{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(PackagesPayload).VisitGetContentPackagesResponse(w)
+	}},
+	{"/content/packages/{package_name}/systems", PackageSystemsPayload{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(PackageSystemsPayload).VisitGetContentPackagesPackageNameSystemsResponse(w)
+	}},
+	{"/content/packages/{package_name}/versions", PackageVersionsPayload{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(PackageVersionsPayload).VisitGetContentPackagesPackageNameVersionsResponse(w)
+	}},
+	{"/content/systems", SystemsPayload{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(SystemsPayload).VisitGetContentSystemsResponse(w)
+	}},
+	{"/content/advisories", AdvisoriesPayload{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(AdvisoriesPayload).VisitGetContentAdvisoriesResponse(w)
+	}},
+	{"/content/advisories/{advisory_name}", AdvisoryDetail{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(AdvisoryDetail).VisitGetContentAdvisoriesAdvisoryNameResponse(w)
+	}},
+	{"/content/advisories/{advisory_name}", notFoundResponse{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(notFoundResponse).VisitGetContentAdvisoriesAdvisoryNameResponse(w)
+	}},
+	{"/content/advisories/{advisory_name}/systems", AdvisorySystemsPayload{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(AdvisorySystemsPayload).VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w)
+	}},
+	{"/content/advisories/{advisory_name}/systems", notFoundResponse{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(notFoundResponse).VisitGetContentAdvisoriesAdvisoryNameSystemsResponse(w)
+	}},
+	{"/content/repos", ReposPayload{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(ReposPayload).VisitGetContentReposResponse(w)
+	}},
+	{"/content/repos/{repo_id}/systems", RepoSystemsPayload{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(RepoSystemsPayload).VisitGetContentReposRepoIdSystemsResponse(w)
+	}},
+	{"/content/repos/{repo_id}/systems", notFoundResponse{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(notFoundResponse).VisitGetContentReposRepoIdSystemsResponse(w)
+	}},
+	{"/systems/{inventory_id}/packages", SystemPackagesPayload{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(SystemPackagesPayload).VisitGetSystemsInventoryIdPackagesResponse(w)
+	}},
+	{"/systems/{inventory_id}/packages", notFoundResponse{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(notFoundResponse).VisitGetSystemsInventoryIdPackagesResponse(w)
+	}},
+	{"/systems/{inventory_id}/packages", forbiddenResponse{}, func(p interface{}, w http.ResponseWriter) error {
+		return p.(forbiddenResponse).VisitGetSystemsInventoryIdPackagesResponse(w)
+	}},
+	// listed by the experiment package, outside of the servers
+	{"/experiments", experiment.ExperimentsPayload{}, func(p interface{}, w http.ResponseWriter) error {
+		return writeJSON(w, p)
+	}},
+}
+
+// TestResponsesMatchSpec checks that the payloads the handlers answer with are valid responses of the api spec
+// and carry no properties the spec does not declare. Every payload is checked once with all its fields set and
+// once with its optional fields left empty.
+func TestResponsesMatchSpec(t *testing.T) {
+	spec := loadSpec(t)
+
+	for _, rc := range responseCases {
+		for _, full := range []bool{true, false} {
+			name := rc.path + " " + reflect.TypeOf(rc.payload).Name()
+			if !full {
+				name += " empty"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				payload := reflect.New(reflect.TypeOf(rc.payload)).Elem()
+				fillValue(payload, "", full)
+
+				w := httptest.NewRecorder()
+				if err := rc.visit(payload.Interface(), w); err != nil {
+					t.Fatal(err)
+				}
+				validateResponse(t, spec, rc.path, w)
+			})
+		}
+	}
+
+	covered := map[string]bool{}
+	for _, rc := range responseCases {
+		covered[rc.path] = true
+	}
+	for path := range spec.Paths {
+		if !covered[path] {
+			t.Errorf("no response of %s is checked", path)
+		}
+	}
+}
+
+// TestErrorResponsesMatchSpec checks the problem documents the strict handlers answer handler errors with, only
+// request errors may tell the client their cause
+func TestErrorResponsesMatchSpec(t *testing.T) {
+	spec := loadSpec(t)
+
+	for _, tt := range []struct {
+		err   error
+		cause string
+		shown bool
+	}{
+		{invalidRequest(errors.New("invalid limit")), "invalid limit", true},
+		{spicedbError(errors.New("dial tcp 10.0.0.1:50051")), "10.0.0.1", false},
+		{databaseError(errors.New(`relation "system_platform" does not exist`), "failed to get counts"), "system_platform", false},
+		{errors.New("unexpected"), "unexpected", false},
+	} {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			strictServerOptions.ResponseErrorHandlerFunc(w, httptest.NewRequest(http.MethodGet, "/content/packages", nil), tt.err)
+			if shown := strings.Contains(w.Body.String(), tt.cause); shown != tt.shown {
+				t.Errorf("cause %q shown to the client is %v: %s", tt.cause, shown, w.Body.String())
+			}
+			validateResponse(t, spec, "/content/packages", w)
+		})
+	}
+}
+
+// loadSpec reads the api spec from its source, the spec embedded in the generated code leaves out the operations
+// which are not generated
+func loadSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+
+	spec, err := openapi3.NewLoader().LoadFromFile("../api/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := spec.Validate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return spec
+}
+
+func validateResponse(t *testing.T, spec *openapi3.T, path string, w *httptest.ResponseRecorder) {
+	t.Helper()
+
+	pathItem := spec.Paths.Find(path)
+	if pathItem == nil || pathItem.Get == nil {
+		t.Fatalf("no GET %s in the spec", path)
+	}
+	operation := pathItem.Get
+
+	body := w.Body.Bytes()
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request: httptest.NewRequest(http.MethodGet, path, nil),
+			Route: &routers.Route{
+				Spec:      spec,
+				Path:      path,
+				PathItem:  pathItem,
+				Method:    http.MethodGet,
+				Operation: operation,
+			},
+		},
+		Status: w.Code,
+		Header: w.Header(),
+		Body:   io.NopCloser(strings.NewReader(string(body))),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+			MultiError:            true,
+		},
+	}
+	if err := openapi3filter.ValidateResponse(context.Background(), input); err != nil {
+		t.Fatalf("%d response does not match the spec: %v\n%s", w.Code, err, body)
+	}
+
+	response := operation.Responses.Get(w.Code)
+	mediaType := response.Value.Content.Get(w.Header().Get("Content-Type"))
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatal(err)
+	}
+	for _, property := range undeclaredProperties(mediaType.Schema.Value, doc, "") {
+		t.Errorf("%d response has property %s which the spec does not declare", w.Code, property)
+	}
+}
+
+// undeclaredProperties lists the properties of the document which the schema does not declare
+func undeclaredProperties(schema *openapi3.Schema, doc interface{}, at string) []string {
+	// a schema without a type takes any document
+	if schema.Type == "" {
+		return nil
+	}
+
+	var undeclared []string
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		additional := schema.AdditionalProperties.Schema
+		for key, value := range v {
+			property, found := schema.Properties[key]
+			switch {
+			case found:
+				undeclared = append(undeclared, undeclaredProperties(property.Value, value, at+"."+key)...)
+			case additional != nil:
+				undeclared = append(undeclared, undeclaredProperties(additional.Value, value, at+"."+key)...)
+			case schema.AdditionalProperties.Has == nil || !*schema.AdditionalProperties.Has:
+				undeclared = append(undeclared, at+"."+key)
+			}
+		}
+	case []interface{}:
+		if schema.Items != nil {
+			for _, item := range v {
+				undeclared = append(undeclared, undeclaredProperties(schema.Items.Value, item, at+"[]")...)
+			}
+		}
+	}
+	sort.Strings(undeclared)
+	return undeclared
+}
+
+// fillValue sets every field of v with a value valid for its json name. Pointers are set and slices and maps get
+// an element when full is true, otherwise they are left nil and empty.
+func fillValue(v reflect.Value, name string, full bool) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if full {
+			v.Set(reflect.New(v.Type().Elem()))
+			fillValue(v.Elem(), name, full)
+		}
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			v.Set(reflect.ValueOf(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldName := strings.Split(field.Tag.Get("json"), ",")[0]
+			if fieldName == "" {
+				fieldName = name
+			}
+			fillValue(v.Field(i), fieldName, full)
+		}
+	case reflect.Slice:
+		// documents stored as json are passed through
+		if v.Type() == reflect.TypeOf(json.RawMessage{}) {
+			if full {
+				v.Set(reflect.ValueOf(json.RawMessage(`{"name":"value"}`)))
+			} else {
+				v.Set(reflect.ValueOf(json.RawMessage("null")))
+			}
+			return
+		}
+		v.Set(reflect.MakeSlice(v.Type(), 0, 1))
+		if full {
+			item := reflect.New(v.Type().Elem()).Elem()
+			fillValue(item, name, full)
+			v.Set(reflect.Append(v, item))
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		if full {
+			key := reflect.New(v.Type().Key()).Elem()
+			value := reflect.New(v.Type().Elem()).Elem()
+			fillValue(key, name, full)
+			fillValue(value, name, full)
+			v.SetMapIndex(key, value)
+		}
+	case reflect.String:
+		v.SetString(stringValue(name))
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.Bool:
+		v.SetBool(true)
+	}
+}
+
+// stringValue gives a value for a string property, properties with an enum or a format need a valid one
+func stringValue(name string) string {
+	switch name {
+	case "update_status":
+		return "Installable"
+	case "requires":
+		return string(experiment.SpiceDB)
+	}
+	return "00000000-0000-0000-0000-000000000001"
+}
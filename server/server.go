@@ -2,13 +2,11 @@ package server
 
 import (
 	"context"
-	"encoding/json"
 	e "errors"
-	"fmt"
 	"io"
 	"net/http"
-	"strconv"
-	"strings"
+	"net/url"
+	"time"
 
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	"github.com/authzed/authzed-go/v1"
@@ -16,31 +14,49 @@ import (
 	"github.com/lib/pq"
 	"github.com/merlante/inventory-access-poc/api"
 	"github.com/merlante/inventory-access-poc/cachecontent"
-	"github.com/pkg/errors"
+	"github.com/merlante/inventory-access-poc/identity"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 )
 
-type InventoryHost struct {
-	Id string
+type PackagesPayload struct {
+	Data  []PackageItem `json:"data"`
+	Meta  ListMeta      `json:"meta"`
+	Links ListLinks     `json:"links"`
 }
 
-type PackagesPayload struct {
-	Data []cachecontent.PackageAccountData `json:"data"`
+// ListMeta describes the whole result set a page of a list response was taken from
+type ListMeta struct {
+	TotalItems int64             `json:"total_items"`
+	Limit      int               `json:"limit"`
+	Sort       string            `json:"sort"`
+	Filter     map[string]string `json:"filter"`
+	// join strategy of the pre-filter experiment the list was read with
+	QueryOptimization string `json:"query_optimization,omitempty"`
+	// SpiceDB revision of the access table the list was read with and when it was applied
+	AccessRevision  string     `json:"access_revision,omitempty"`
+	AccessAppliedAt *time.Time `json:"access_applied_at,omitempty"`
+	// comparison of the list with the host pre-filter, on request
+	PreFilterComparison *PreFilterComparison `json:"pre_filter_comparison,omitempty"`
 }
 
-func (p PackagesPayload) VisitGetContentPackagesResponse(w http.ResponseWriter) error {
-	jsonResponse, err := json.Marshal(p)
-	if err != nil {
-		return err
-	}
+// ListLinks point to the first page and the pages adjacent to the current one
+type ListLinks struct {
+	First    string `json:"first"`
+	Next     string `json:"next,omitempty"`
+	Previous string `json:"previous,omitempty"`
+}
 
-	w.Write(jsonResponse)
-	if err != nil {
-		return err
+func listLink(path string, query url.Values, cursor string) string {
+	if cursor != "" {
+		query.Set("cursor", cursor)
 	}
+	return path + "?" + query.Encode()
+}
 
-	return nil
+func (p PackagesPayload) VisitGetContentPackagesResponse(w http.ResponseWriter) error {
+	return writeJSON(w, p)
 }
 
 type PreFilterServer struct {
@@ -49,68 +65,12 @@ type PreFilterServer struct {
 	PostgresConn  *pgx.Conn
 }
 
-func getIdsFromInventoryHost(hosts []InventoryHost) []string {
-	var ids []string
-	for _, host := range hosts {
-		ids = append(ids, host.Id)
-	}
-	return ids
-}
-
-func (c *PreFilterServer) GetContentPackagesWithDatabase(ctx context.Context, request api.GetContentPackagesRequestObject) (api.GetContentPackagesResponseObject, error) {
-	ctx, span := c.Tracer.Start(ctx, "GetContentPackages")
-	defer span.End()
-
-	user, accountId, found := getIdentityFromContext(ctx)
-	if found {
-		fmt.Printf("indentity found in request: %s %d\n", user, accountId)
-	}
-
-	limit := limitHostIDs(ctx)
-
-	inventoryHosts := make([]InventoryHost, 0)
-	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
-		result := tx.Raw("SELECT id FROM inventory.hosts LIMIT ?", limit).Scan(&inventoryHosts)
-		if result.Error != nil {
-			// Handle error
-			fmt.Println(result.Error)
-		}
-		return nil
-	})
-
-	hostIDs := getIdsFromInventoryHost(inventoryHosts)
-
-	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
-
-	packageAccountData := make([]cachecontent.PackageAccountData, 0)
-
-	err = packagesByHostIDs(&packageAccountData, accountId, hostIDs)
-
-	if err != nil {
-		return nil, err
-	}
-
-	packages, err := GetPackagesPayload(packageAccountData)
-
-	pgSpan.End()
-
-	return packages, err
-}
-
-func (c *PreFilterServer) GetContentPackages(ctx context.Context, request api.GetContentPackagesRequestObject) (api.GetContentPackagesResponseObject, error) {
-	ctx, span := c.Tracer.Start(ctx, "GetContentPackages")
-	defer span.End()
-
-	// TODO: user will be needed in spicedb queries -- set Authorization request header to the userid
-
-	user, accountId, found := getIdentityFromContext(ctx)
-	if found {
-		fmt.Printf("indentity found in request: %s %d\n", user, accountId)
-	}
-
+// lookupHostIDs asks SpiceDB for the ids of all the inventory hosts the user can read
+func (c *PreFilterServer) lookupHostIDs(ctx context.Context, user string) ([]string, error) {
 	_, spiceSpan := c.Tracer.Start(ctx, "SpiceDB pre-filter call")
+	defer spiceSpan.End()
 
-	lrClient, err := c.SpicedbClient.LookupResources(ctx, &v1.LookupResourcesRequest{
+	return lookupResourceIDs(ctx, c.SpicedbClient, &v1.LookupResourcesRequest{
 		ResourceObjectType: "inventory/host",
 		Permission:         "read",
 		Subject: &v1.SubjectReference{
@@ -120,39 +80,53 @@ func (c *PreFilterServer) GetContentPackages(ctx context.Context, request api.Ge
 			},
 		},
 	})
+}
 
+// lookupResourceIDs reads the ids of all the resources found by the lookup
+func lookupResourceIDs(ctx context.Context, client *authzed.Client, request *v1.LookupResourcesRequest) ([]string, error) {
+	lrClient, err := client.LookupResources(ctx, request)
 	if err != nil {
-		fmt.Errorf("spicedb error: %v", err)
-		return nil, err
+		return nil, spicedbError(err)
 	}
 
-	var hostIDs []string
+	var ids []string
 	for {
 		next, err := lrClient.Recv()
 		if e.Is(err, io.EOF) {
 			break
 		}
 		if err != nil {
-			fmt.Errorf("spicedb error: %v", err)
-			return nil, err
+			return nil, spicedbError(err)
 		}
 
-		hostIDs = append(hostIDs, next.GetResourceObjectId()) // e.g. service or inventory group
+		ids = append(ids, next.GetResourceObjectId())
 	}
 
-	spiceSpan.End()
+	return ids, nil
+}
 
-	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+func (c *PreFilterServer) GetContentPackages(ctx context.Context, request api.GetContentPackagesRequestObject) (api.GetContentPackagesResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentPackages")
+	defer span.End()
+
+	opts, err := getPackageListOptions(ctx, request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
 
-	packageAccountData := make([]cachecontent.PackageAccountData, 0)
-	countError := packagesByHostIDs(&packageAccountData, accountId, hostIDs)
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	strategyName, strategy := extractQueryOptimization(ctx)
+	span.SetAttributes(attribute.String("query.optimization", strategyName))
+
+	page := packagePage{}
+	countError := strategy(ctx, c, &page, accountId, user, opts)
 	if countError != nil {
 		return nil, countError
 	}
 
-	packages, err := GetPackagesPayload(packageAccountData)
-
-	pgSpan.End()
+	packages, err := GetPackagesPayload(page, opts)
+	packages.Meta.QueryOptimization = strategyName
 
 	return packages, err
 }
@@ -170,263 +144,215 @@ func (c *BaselineServer) GetContentPackages(ctx context.Context, request api.Get
 
 	//Any SpiceDB queries here (not metered)
 
-	user, accountId, found := getIdentityFromContext(ctx)
-	if found {
-		fmt.Printf("identity found in request: %s %d\n", user, accountId)
+	opts, err := getPackageListOptions(ctx, request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
 	}
 
+	_, accountId, _ := getIdentityFromContext(ctx)
+
 	ctx, span := c.Tracer.Start(ctx, "GetContentPackages")
 	defer span.End()
 
 	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
 
-	packageAccountData := make([]cachecontent.PackageAccountData, 0)
+	page := packagePage{}
 
-	countError := packagesByAccount(&packageAccountData, accountId)
+	countError := packagesByAccount(&page, accountId, opts)
 	if countError != nil {
 		return nil, countError
 	}
 
-	packages, err := GetPackagesPayload(packageAccountData)
+	packages, err := GetPackagesPayload(page, opts)
 	pgSpan.End()
 
 	return packages, err
 }
 
-func GetPackagesPayload(acountData []cachecontent.PackageAccountData) (PackagesPayload, error) {
-	payload := PackagesPayload{}
-	for _, v := range acountData {
+func GetPackagesPayload(page packagePage, opts packageListOptions) (PackagesPayload, error) {
+	payload := PackagesPayload{
+		Data:  make([]PackageItem, 0, len(page.Items)),
+		Meta:  opts.meta(page.Total),
+		Links: opts.links(page),
+	}
+	for _, v := range page.Items {
 		payload.Data = append(payload.Data, v)
 	}
 
 	return payload, nil
 }
 
-func limitHostIDs(ctx context.Context) string {
-	limitHostIDsParam, ok := ctx.Value("Limit-Host-IDs").(string)
-	if !ok {
-		return "1"
-	}
-
-	return limitHostIDsParam
-}
-
+// getIdentityFromContext gives the spicedb user id and the rh_account id of the caller
 func getIdentityFromContext(ctx context.Context) (user string, rhAccount int64, found bool) {
-	userInfo, ok := ctx.Value("user").(string)
-	if !ok {
-		// Handle case where the value is nil or not a string
+	id, found := identity.FromContext(ctx)
+	if !found {
 		return "", 0, false
 	}
 
-	// Split the userInfo string to extract the user and rhAccount
-	parts := strings.Split(userInfo, ";")
-	if len(parts) != 2 {
-		// Handle error if the format is not as expected
-		return "", 0, false
-	}
+	return id.UserID, id.RhAccountID, true
+}
 
-	// Assign the split values to user
-	user = parts[0]
+// hostFilter restricts a query joining inventory.hosts as ih to the hosts the user has access to
+type hostFilter func(q *gorm.DB) *gorm.DB
 
-	// Convert the rhAccount part to int64
-	var err error
-	rhAccount, err = strconv.ParseInt(parts[1], 10, 64)
-	if err != nil {
-		// Handle error if the conversion fails
-		return "", 0, false
+// hostIDsFilter keeps the hosts looked up in SpiceDB
+func hostIDsFilter(hostIDs []string) hostFilter {
+	return func(q *gorm.DB) *gorm.DB {
+		return q.Where("ih.id IN ?", hostIDs)
 	}
-
-	return user, rhAccount, true
 }
 
-func extractQueryOptimalization(ctx context.Context) string {
-	optimalization, ok := ctx.Value("query-optimalization").(string)
-	if !ok {
-		return ""
-	}
+// ungroupedHostsFilter keeps the ungrouped hosts, which are all the hosts of an account in the test data
+func ungroupedHostsFilter(q *gorm.DB) *gorm.DB {
+	return q.Where("ih.groups = '[]'")
+}
 
-	return optimalization
+// packageAggregateColumns count the system packages of sp and spkg per package name pn
+const packageAggregateColumns = `
+	sp.rh_account_id rh_account_id,
+	spkg.name_id package_name_id,
+	pn.name name,
+	max(spkg.latest_evra COLLATE "numeric") latest_evra,
+	count(*) as systems_installed,
+	count(*) filter (where update_status(spkg.update_data) = 'Installable') as systems_installable,
+	count(*) filter (where update_status(spkg.update_data) != 'None') as systems_applicable
+`
+
+// packageAggregate counts the systems of the account per installed package, restricted by the filter options.
+// The systems are joined to inventory.hosts as ih but not yet restricted to the hosts the user has access to.
+func packageAggregate(tx *gorm.DB, accID int64, opts packageListOptions) *gorm.DB {
+	q := tx.Table("system_platform sp").
+		Select(packageAggregateColumns).
+		Joins("JOIN system_package spkg ON sp.id = spkg.system_id AND sp.rh_account_id = spkg.rh_account_id").
+		Joins("JOIN rh_account acc ON sp.rh_account_id = acc.id").
+		Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
+		Joins("JOIN package_name pn ON spkg.name_id = pn.id").
+		Where("sp.rh_account_id = ?", accID)
+
+	return filterSystemProfile(filterHostTags(q, opts.HostTags), opts.SystemProfile).
+		Group("sp.rh_account_id, spkg.name_id, pn.name")
 }
 
-func packagesByHostIDs(pkgSysCounts *[]cachecontent.PackageAccountData, accID int64, hostIDs []string) error {
+func packagesByHostIDs(page *packagePage, accID int64, hostIDs []string, opts packageListOptions) error {
 	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
-		q := tx.Table("system_platform sp").
-			Select(`
-				sp.rh_account_id rh_account_id,
-				spkg.name_id package_name_id,
-				count(*) as systems_installed,
-				count(*) filter (where update_status(spkg.update_data) = 'Installable') as systems_installable,
-				count(*) filter (where update_status(spkg.update_data) != 'None') as systems_applicable
-			`).
-			Joins("JOIN system_package spkg ON sp.id = spkg.system_id AND sp.rh_account_id = spkg.rh_account_id").
-			Joins("JOIN rh_account acc ON sp.rh_account_id = acc.id").
-			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
-			Where("sp.rh_account_id = ?", accID).
-			Where("ih.id IN ?", hostIDs).
-			Group("sp.rh_account_id, spkg.name_id").
-			Order("sp.rh_account_id, spkg.name_id")
+		q := hostIDsFilter(hostIDs)(packageAggregate(tx, accID, opts))
 
-		return q.Find(pkgSysCounts).Error
+		return fetchPackagePage(tx, q, opts, page)
 	})
 
-	return errors.Wrap(err, "failed to get counts")
+	return databaseError(err, "failed to get counts")
 }
 
-func packagesByAccount(pkgSysCounts *[]cachecontent.PackageAccountData, accID int64) error {
+func packagesByAccount(page *packagePage, accID int64, opts packageListOptions) error {
 	//Account IDs are a passthrough representation of inventory groups because each account only has ungrouped hosts
 	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
-		q := tx.Table("system_platform sp").
-			Select(`
-				sp.rh_account_id rh_account_id,
-				spkg.name_id package_name_id,
-				count(*) as systems_installed,
-				count(*) filter (where update_status(spkg.update_data) = 'Installable') as systems_installable,
-				count(*) filter (where update_status(spkg.update_data) != 'None') as systems_applicable
-			`).
-			Joins("JOIN system_package spkg ON sp.id = spkg.system_id AND sp.rh_account_id = spkg.rh_account_id").
-			Joins("JOIN rh_account acc ON sp.rh_account_id = acc.id").
-			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
-			Where("ih.groups = '[]'").
-			Where("sp.rh_account_id = ?", accID).
-			Group("sp.rh_account_id, spkg.name_id").
-			Order("sp.rh_account_id, spkg.name_id")
+		q := ungroupedHostsFilter(packageAggregate(tx, accID, opts))
 
-		return q.Find(pkgSysCounts).Error
+		return fetchPackagePage(tx, q, opts, page)
 	})
 
-	return errors.Wrap(err, "failed to get counts")
+	return databaseError(err, "failed to get counts")
 }
 
-func packagesByHostIDsCTE(pkgSysCounts *[]cachecontent.PackageAccountData, accID int64, hostIDs []string) error {
-	fmt.Printf("packagesByHostIDsCTE")
-
+func packagesByHostIDsCTE(page *packagePage, accID int64, hostIDs []string, opts packageListOptions) error {
 	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
-		cteQuery := `
-    WITH CTE_SystemUpdateStatus AS (
-        SELECT
-            sp.rh_account_id,
-            spkg.name_id,
-            spkg.update_data,
-            update_status(spkg.update_data) as update_status
-        FROM
-            system_platform sp
-            JOIN system_package spkg ON sp.id = spkg.system_id AND sp.rh_account_id = spkg.rh_account_id
-            JOIN inventory.hosts ih ON sp.inventory_id = ih.id
-        WHERE
-            ih.id IN ?
-    )
-    SELECT
-        s.rh_account_id,
-        s.name_id,
-        count(*) as systems_installed,
-        count(*) filter (where s.update_status = 'Installable') as systems_installable,
-        count(*) filter (where s.update_status != 'None') as systems_applicable
-    FROM
-        CTE_SystemUpdateStatus s
-    GROUP BY
-        s.rh_account_id, s.name_id
-    ORDER BY
-        s.rh_account_id, s.name_id
-    `
-		return tx.Raw(cteQuery, hostIDs).Scan(pkgSysCounts).Error
+		systemUpdateStatus := tx.Table("system_platform sp").
+			Select(`
+				sp.rh_account_id,
+				spkg.name_id,
+				spkg.latest_evra,
+				update_status(spkg.update_data) as update_status
+			`).
+			Joins("JOIN system_package spkg ON sp.id = spkg.system_id AND sp.rh_account_id = spkg.rh_account_id").
+			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
+			Where("sp.rh_account_id = ?", accID)
+		systemUpdateStatus = hostIDsFilter(hostIDs)(systemUpdateStatus)
+		systemUpdateStatus = filterSystemProfile(filterHostTags(systemUpdateStatus, opts.HostTags), opts.SystemProfile)
+
+		q := tx.Raw(`
+			WITH CTE_SystemUpdateStatus AS (?)
+			SELECT
+				s.rh_account_id rh_account_id,
+				s.name_id package_name_id,
+				pn.name name,
+				max(s.latest_evra COLLATE "numeric") latest_evra,
+				count(*) as systems_installed,
+				count(*) filter (where s.update_status = 'Installable') as systems_installable,
+				count(*) filter (where s.update_status != 'None') as systems_applicable
+			FROM
+				CTE_SystemUpdateStatus s
+				JOIN package_name pn ON s.name_id = pn.id
+			GROUP BY
+				s.rh_account_id, s.name_id, pn.name
+			`, systemUpdateStatus)
+
+		return fetchPackagePage(tx, q, opts, page)
 	})
 
-	return errors.Wrap(err, "failed to get counts")
+	return databaseError(err, "failed to get counts")
 }
 
-func packagesByHostIDsTempTable(pkgSysCounts *[]cachecontent.PackageAccountData, accID int64, hostIDs []string) error {
-	fmt.Printf("packagesByHostIDsTempTable")
-
+func packagesByHostIDsTempTable(page *packagePage, accID int64, hostIDs []string, opts packageListOptions) error {
 	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
-		// Step 1: Create a temporary table
-		if err := tx.Exec("CREATE TEMPORARY TABLE TempHostIDs (id UUID)").Error; err != nil {
+		// the table only lives until the end of the transaction
+		if err := tx.Exec("CREATE TEMPORARY TABLE TempHostIDs (id UUID PRIMARY KEY) ON COMMIT DROP").Error; err != nil {
 			return err
 		}
 
-		// Step 2: Insert hostIDs into the temporary table
-		for _, id := range hostIDs {
-			if err := tx.Exec("INSERT INTO TempHostIDs (id) VALUES (?)", id).Error; err != nil {
-				return err
-			}
-		}
-
-		// Step 3: Modify the main query to join with the temporary table
-		q := tx.Table("system_platform sp").
-			Select(`
-            sp.rh_account_id rh_account_id,
-            spkg.name_id package_name_id,
-            count(*) as systems_installed,
-            count(*) filter (where update_status(spkg.update_data) = 'Installable') as systems_installable,
-            count(*) filter (where update_status(spkg.update_data) != 'None') as systems_applicable
-        `).
-			Joins("JOIN system_package spkg ON sp.id = spkg.system_id AND sp.rh_account_id = spkg.rh_account_id").
-			Joins("JOIN rh_account acc ON sp.rh_account_id = acc.id").
-			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
-			Joins("JOIN TempHostIDs th ON ih.id = th.id"). // Join with the temporary table
-			Group("sp.rh_account_id, spkg.name_id").
-			Order("sp.rh_account_id, spkg.name_id")
-
-		// Execute the query
-		if err := q.Find(pkgSysCounts).Error; err != nil {
+		if err := tx.Exec("INSERT INTO TempHostIDs (id) SELECT unnest(?::uuid[])", pq.Array(hostIDs)).Error; err != nil {
 			return err
 		}
 
-		// Optional: Drop the temporary table if needed
-		return tx.Exec("DROP TABLE TempHostIDs").Error
+		q := packageAggregate(tx, accID, opts).
+			Joins("JOIN TempHostIDs th ON ih.id = th.id")
+
+		return fetchPackagePage(tx, q, opts, page)
 	})
 
-	return errors.Wrap(err, "failed to get counts")
+	return databaseError(err, "failed to get counts")
 }
 
-func packagesByHostCTEinsteadOfTempTable(pkgSysCounts *[]cachecontent.PackageAccountData, accID int64, hostIDs []string) error {
-	fmt.Printf("packagesByHostCTEinsteadOfTempTable")
-
+func packagesByHostCTEinsteadOfTempTable(page *packagePage, accID int64, hostIDs []string, opts packageListOptions) error {
 	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
-		// Define the CTE and main query
-		cteAndQuery := `
+		aggregate := packageAggregate(tx, accID, opts).
+			Joins("JOIN HostIDCTE hcte ON ih.id = hcte.id")
+
+		q := tx.Raw(`
 			WITH HostIDCTE AS (
-				 SELECT unnest(?::uuid[]) AS id  -- Convert the hostIDs slice to a set of rows
+				SELECT unnest(?::uuid[]) AS id
 			)
-			SELECT
-				sp.rh_account_id rh_account_id,
-				spkg.name_id package_name_id,
-				count(*) as systems_installed,
-				count(*) filter (where update_status(spkg.update_data) = 'Installable') as systems_installable,
-				count(*) filter (where update_status(spkg.update_data) != 'None') as systems_applicable
-			FROM
-				system_platform sp
-				JOIN system_package spkg ON sp.id = spkg.system_id AND sp.rh_account_id = spkg.rh_account_id
-				JOIN rh_account acc ON sp.rh_account_id = acc.id
-				JOIN inventory.hosts ih ON sp.inventory_id = ih.id
-				JOIN HostIDCTE hcte ON ih.id = hcte.id
-			GROUP BY
-				sp.rh_account_id, spkg.name_id
-			ORDER BY
-				sp.rh_account_id, spkg.name_id
-			`
+			?`, pq.Array(hostIDs), aggregate)
 
-		return tx.Raw(cteAndQuery, pq.Array(hostIDs)).Scan(pkgSysCounts).Error
+		return fetchPackagePage(tx, q, opts, page)
 	})
 
-	return errors.Wrap(err, "failed to get counts")
+	return databaseError(err, "failed to get counts")
 }
 
-func packagesByHostIDsNoCounts(pkgSysCounts *[]cachecontent.PackageAccountData, accID int64, hostIDs []string) error {
+// packagesByHostIDsNoCounts only finds the installed packages, the system counts are reported as 0
+// and so are the counts the patches_available filter and sorting use
+func packagesByHostIDsNoCounts(page *packagePage, accID int64, hostIDs []string, opts packageListOptions) error {
 	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
 		q := tx.Table("system_platform sp").
 			Select(`
 				sp.rh_account_id rh_account_id,
-				spkg.name_id package_name_id
+				spkg.name_id package_name_id,
+				pn.name name,
+				NULL latest_evra,
+				0 systems_installed,
+				0 systems_installable,
+				0 systems_applicable
 			`).
 			Joins("JOIN system_package spkg ON sp.id = spkg.system_id AND sp.rh_account_id = spkg.rh_account_id").
 			Joins("JOIN rh_account acc ON sp.rh_account_id = acc.id").
 			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
-			Where("ih.id IN ?", hostIDs).
-			Group("sp.rh_account_id, spkg.name_id").
-			Order("sp.rh_account_id, spkg.name_id")
+			Joins("JOIN package_name pn ON spkg.name_id = pn.id").
+			Where("sp.rh_account_id = ?", accID)
+		q = filterSystemProfile(filterHostTags(hostIDsFilter(hostIDs)(q), opts.HostTags), opts.SystemProfile).
+			Group("sp.rh_account_id, spkg.name_id, pn.name")
 
-		return q.Find(pkgSysCounts).Error
+		return fetchPackagePage(tx, q, opts, page)
 	})
 
-	return errors.Wrap(err, "failed to get counts")
+	return databaseError(err, "failed to get counts")
 }
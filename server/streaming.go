@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	e "errors"
+	"io"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// host ids received from SpiceDB ahead of COPY
+const streamedHostIDsBuffer = 1024
+
+// StreamingServer copies the hosts of the LookupResources stream into a temporary table while the stream is still
+// being received and joins the package list to it. The other endpoints are answered like in the pre-filter experiment.
+type StreamingServer struct {
+	PreFilterServer
+}
+
+func (c *StreamingServer) GetContentPackages(ctx context.Context, request api.GetContentPackagesRequestObject) (api.GetContentPackagesResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentPackages")
+	defer span.End()
+
+	opts, err := getPackageListOptions(ctx, request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	page := packagePage{}
+	// COPY needs the pgx connection underneath the transaction, so the transaction is begun on a dedicated connection
+	err = cachecontent.CancelableReadReplicaDB().Connection(func(db *gorm.DB) error {
+		conn := db.Statement.ConnPool.(*sql.Conn)
+
+		return db.Transaction(func(tx *gorm.DB) error {
+			err := tx.Exec("CREATE TEMPORARY TABLE streamed_host_ids (id UUID) ON COMMIT DROP").Error
+			if err != nil {
+				return err
+			}
+
+			copied, err := c.copyHostIDs(ctx, conn, user)
+			if err != nil {
+				return err
+			}
+			span.SetAttributes(attribute.Int64("hosts.copied", copied))
+
+			// temporary tables are not analyzed automatically, the planner would guess their size
+			if err := tx.Exec("ANALYZE streamed_host_ids").Error; err != nil {
+				return err
+			}
+
+			_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+			defer pgSpan.End()
+
+			// a semi join, LookupResources may return a host more than once
+			q := packageAggregate(tx, accountId, opts).
+				Where("ih.id IN (SELECT id FROM streamed_host_ids)")
+
+			return fetchPackagePage(tx, q, opts, &page)
+		})
+	})
+	if err != nil {
+		// SpiceDB errors are already marked
+		var herr *handlerError
+		if e.As(err, &herr) {
+			return nil, err
+		}
+		return nil, databaseError(err, "failed to get counts")
+	}
+
+	return GetPackagesPayload(page, opts)
+}
+
+// copyHostIDs copies the hosts the user can read into streamed_host_ids on the connection. The stream is received in
+// the background so that SpiceDB keeps sending while COPY writes to Postgres.
+func (c *StreamingServer) copyHostIDs(ctx context.Context, conn *sql.Conn, user string) (int64, error) {
+	ctx, spiceSpan := c.Tracer.Start(ctx, "SpiceDB streaming pre-filter call")
+	defer spiceSpan.End()
+
+	// stops the receiving goroutine when COPY fails before the stream ended
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lrClient, err := c.SpicedbClient.LookupResources(ctx, &v1.LookupResourcesRequest{
+		ResourceObjectType: "inventory/host",
+		Permission:         "read",
+		Subject: &v1.SubjectReference{
+			Object: &v1.ObjectReference{
+				ObjectType: "user",
+				ObjectId:   user,
+			},
+		},
+	})
+	if err != nil {
+		return 0, spicedbError(err)
+	}
+
+	results := make(chan lookupResult, streamedHostIDsBuffer)
+	go func() {
+		defer close(results)
+		for {
+			next, err := lrClient.Recv()
+			if e.Is(err, io.EOF) {
+				return
+			}
+
+			select {
+			case results <- lookupResult{id: next.GetResourceObjectId(), err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	source := &hostIDSource{results: results}
+	var copied int64
+	err = conn.Raw(func(driverConn any) error {
+		var err error
+		copied, err = driverConn.(*stdlib.Conn).Conn().CopyFrom(ctx, pgx.Identifier{"streamed_host_ids"}, []string{"id"}, source)
+		return err
+	})
+	if source.streamErr != nil {
+		return 0, spicedbError(source.streamErr)
+	}
+	return copied, errors.Wrap(err, "failed to copy host ids")
+}
+
+type lookupResult struct {
+	id  string
+	err error
+}
+
+// hostIDSource feeds the received host ids to COPY, it ends at the end of the stream or at the first error
+type hostIDSource struct {
+	results   <-chan lookupResult
+	id        pgtype.UUID
+	streamErr error
+	err       error
+}
+
+func (s *hostIDSource) Next() bool {
+	result, ok := <-s.results
+	if !ok {
+		return false
+	}
+	if result.err != nil {
+		s.streamErr = result.err
+		return false
+	}
+
+	s.err = s.id.Scan(result.id)
+	return s.err == nil
+}
+
+func (s *hostIDSource) Values() ([]any, error) {
+	return []any{s.id}, nil
+}
+
+func (s *hostIDSource) Err() error {
+	if s.streamErr != nil {
+		return s.streamErr
+	}
+	return s.err
+}
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/google/uuid"
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// SystemPackageItem is a package installed on a system
+type SystemPackageItem struct {
+	Name    string  `json:"name"`
+	EVRA    string  `json:"evra"`
+	Summary *string `json:"summary"`
+	// advisory which released the installed package
+	Advisory     *string               `json:"advisory"`
+	UpdateStatus string                `json:"update_status"`
+	Updates      []SystemPackageUpdate `json:"updates"`
+}
+
+// SystemPackageUpdate is an update of an installed package together with the advisory which released it,
+// PackageUpdate alone leaves the advisory out of json
+type SystemPackageUpdate struct {
+	cachecontent.PackageUpdate
+	Advisory string `json:"advisory"`
+}
+
+type SystemPackagesPayload struct {
+	InventoryID string              `json:"inventory_id"`
+	Data        []SystemPackageItem `json:"data"`
+}
+
+func (p SystemPackagesPayload) VisitGetSystemsInventoryIdPackagesResponse(w http.ResponseWriter) error {
+	return writeJSON(w, p)
+}
+
+func (r notFoundResponse) VisitGetSystemsInventoryIdPackagesResponse(w http.ResponseWriter) error {
+	return r.visit(w)
+}
+
+func (r forbiddenResponse) VisitGetSystemsInventoryIdPackagesResponse(w http.ResponseWriter) error {
+	return r.visit(w)
+}
+
+func (c *PreFilterServer) GetSystemsInventoryIdPackages(ctx context.Context, request api.GetSystemsInventoryIdPackagesRequestObject) (api.GetSystemsInventoryIdPackagesResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetSystemsInventoryIdPackages")
+	defer span.End()
+
+	if _, err := uuid.Parse(request.InventoryId); err != nil {
+		return nil, invalidRequest(errors.Wrap(err, "invalid inventory_id"))
+	}
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres system lookup")
+	systemID, err := findSystemID(accountId, request.InventoryId, nil)
+	pgSpan.End()
+	if err != nil {
+		return nil, err
+	}
+	if systemID == 0 {
+		return notFoundResponse{Error: fmt.Sprintf("system %s not found", request.InventoryId)}, nil
+	}
+
+	_, spiceSpan := c.Tracer.Start(ctx, "SpiceDB check call")
+	resp, err := c.SpicedbClient.CheckPermission(ctx, &v1.CheckPermissionRequest{
+		Resource: &v1.ObjectReference{
+			ObjectType: "patch/system",
+			ObjectId:   strconv.FormatInt(systemID, 10),
+		},
+		Permission: "read",
+		Subject: &v1.SubjectReference{
+			Object: &v1.ObjectReference{
+				ObjectType: "user",
+				ObjectId:   user,
+			},
+		},
+	})
+	spiceSpan.End()
+	if err != nil {
+		return nil, spicedbError(err)
+	}
+	if resp.GetPermissionship() != v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION {
+		return forbiddenResponse{Error: fmt.Sprintf("no access to system %s", request.InventoryId)}, nil
+	}
+
+	_, pgSpan = c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return systemPackages(accountId, systemID, request.InventoryId)
+}
+
+func (c *BaselineServer) GetSystemsInventoryIdPackages(ctx context.Context, request api.GetSystemsInventoryIdPackagesRequestObject) (api.GetSystemsInventoryIdPackagesResponseObject, error) {
+	if _, err := uuid.Parse(request.InventoryId); err != nil {
+		return nil, invalidRequest(errors.Wrap(err, "invalid inventory_id"))
+	}
+
+	_, accountId, _ := getIdentityFromContext(ctx)
+
+	ctx, span := c.Tracer.Start(ctx, "GetSystemsInventoryIdPackages")
+	defer span.End()
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	systemID, err := findSystemID(accountId, request.InventoryId, nil)
+	if err != nil {
+		return nil, err
+	}
+	if systemID == 0 {
+		return notFoundResponse{Error: fmt.Sprintf("system %s not found", request.InventoryId)}, nil
+	}
+
+	accessibleID, err := findSystemID(accountId, request.InventoryId, ungroupedHostsFilter)
+	if err != nil {
+		return nil, err
+	}
+	if accessibleID == 0 {
+		return forbiddenResponse{Error: fmt.Sprintf("no access to system %s", request.InventoryId)}, nil
+	}
+
+	return systemPackages(accountId, systemID, request.InventoryId)
+}
+
+// findSystemID returns the system_platform id of the account's system, 0 when there is no such system
+// or it does not pass the host filter
+func findSystemID(accID int64, inventoryID string, filter hostFilter) (int64, error) {
+	var ids []int64
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		q := tx.Table("system_platform sp").
+			Where("sp.rh_account_id = ?", accID).
+			Where("sp.inventory_id = ?", inventoryID)
+		if filter != nil {
+			q = filter(q.Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id"))
+		}
+		return q.Limit(1).Pluck("sp.id", &ids).Error
+	})
+	if err != nil {
+		return 0, databaseError(err, "failed to find system")
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return ids[0], nil
+}
+
+// systemPackages lists the packages installed on the system
+func systemPackages(accID int64, systemID int64, inventoryID string) (SystemPackagesPayload, error) {
+	var rows []struct {
+		Name         string
+		EVRA         string `gorm:"column:evra"`
+		Summary      *string
+		Advisory     *string
+		UpdateStatus string
+		UpdateData   []byte
+	}
+
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		return tx.Table("system_package spkg").
+			Select(`
+				pn.name name,
+				p.evra evra,
+				COALESCE(ss.value, pn.summary) summary,
+				am.name advisory,
+				update_status(spkg.update_data) update_status,
+				spkg.update_data update_data
+			`).
+			Joins("JOIN package p ON spkg.package_id = p.id").
+			Joins("JOIN package_name pn ON spkg.name_id = pn.id").
+			Joins("LEFT JOIN strings ss ON p.summary_hash = ss.id").
+			Joins("LEFT JOIN advisory_metadata am ON p.advisory_id = am.id").
+			Where("spkg.rh_account_id = ?", accID).
+			Where("spkg.system_id = ?", systemID).
+			Order(`pn.name, p.evra COLLATE "numeric"`).
+			Scan(&rows).Error
+	})
+	if err != nil {
+		return SystemPackagesPayload{}, databaseError(err, "failed to get system packages")
+	}
+
+	payload := SystemPackagesPayload{
+		InventoryID: inventoryID,
+		Data:        make([]SystemPackageItem, 0, len(rows)),
+	}
+	for _, row := range rows {
+		updates := []SystemPackageUpdate{}
+		if len(row.UpdateData) > 0 {
+			if err = json.Unmarshal(row.UpdateData, &updates); err != nil {
+				return SystemPackagesPayload{}, errors.Wrapf(err, "invalid update_data of %s", row.Name)
+			}
+		}
+		for i := range updates {
+			updates[i].PackageUpdate.Advisory = updates[i].Advisory
+		}
+
+		payload.Data = append(payload.Data, SystemPackageItem{
+			Name:         row.Name,
+			EVRA:         row.EVRA,
+			Summary:      row.Summary,
+			Advisory:     row.Advisory,
+			UpdateStatus: row.UpdateStatus,
+			Updates:      updates,
+		})
+	}
+
+	return payload, nil
+}
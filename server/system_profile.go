@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/merlante/inventory-access-poc/problem"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const systemProfileFilterPrefix = "filter[system_profile]"
+
+type systemProfileContextKey struct{}
+
+var systemProfileSegment = regexp.MustCompile(`^\[([A-Za-z0-9_]+)\]`)
+
+// operators which can end a system profile filter, eq is used when there is none
+var systemProfileOperators = map[string]bool{
+	"eq":       true,
+	"neq":      true,
+	"gt":       true,
+	"gte":      true,
+	"lt":       true,
+	"lte":      true,
+	"in":       true,
+	"contains": true,
+	"nil":      true,
+	"not_nil":  true,
+}
+
+// systemProfileFilter is one filter[system_profile][...]=value parameter, e.g.
+// filter[system_profile][operating_system][major][gte]=8 or filter[system_profile][sap_sids][contains]=ABC
+type systemProfileFilter struct {
+	// Param and Value are the query parameter as given, to be reported in meta and repeated in links
+	Param    string
+	Value    string
+	Path     []string
+	Operator string
+}
+
+func parseSystemProfileFilter(param, value string) (systemProfileFilter, error) {
+	filter := systemProfileFilter{Param: param, Value: value, Operator: "eq"}
+
+	rest := strings.TrimPrefix(param, systemProfileFilterPrefix)
+	for rest != "" {
+		m := systemProfileSegment.FindStringSubmatch(rest)
+		if m == nil {
+			return filter, errors.Errorf("invalid system profile filter %s", param)
+		}
+		filter.Path = append(filter.Path, m[1])
+		rest = rest[len(m[0]):]
+	}
+
+	if n := len(filter.Path); n > 1 && systemProfileOperators[filter.Path[n-1]] {
+		filter.Operator = filter.Path[n-1]
+		filter.Path = filter.Path[:n-1]
+	}
+	if len(filter.Path) == 0 {
+		return filter, errors.Errorf("invalid system profile filter %s, no field given", param)
+	}
+
+	switch filter.Operator {
+	case "gt", "gte", "lt", "lte":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return filter, errors.Errorf("invalid system profile filter %s, %q is not a number", param, value)
+		}
+	}
+
+	return filter, nil
+}
+
+// parseSystemProfileFilters picks the system profile filters out of the query string, several filters
+// on the same field must all match
+func parseSystemProfileFilters(query map[string][]string) ([]systemProfileFilter, error) {
+	params := make([]string, 0)
+	for param := range query {
+		if strings.HasPrefix(param, systemProfileFilterPrefix) {
+			params = append(params, param)
+		}
+	}
+	sort.Strings(params)
+
+	var filters []systemProfileFilter
+	for _, param := range params {
+		for _, value := range query[param] {
+			filter, err := parseSystemProfileFilter(param, value)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, filter)
+		}
+	}
+	return filters, nil
+}
+
+// SystemProfileFilterMiddleware parses the filter[system_profile][...] parameters into the request context and
+// removes them from the query. The api spec describes them as the deepObject filter, but their nesting depends on the
+// system profile field and they may repeat, which the generated parameter binding rejects.
+func SystemProfileFilterMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		filters, err := parseSystemProfileFilters(query)
+		if err != nil {
+			problem.New(http.StatusBadRequest, err.Error()).WithType(problem.TypeValidation).Write(w)
+			return
+		}
+
+		if len(filters) > 0 {
+			for _, f := range filters {
+				query.Del(f.Param)
+			}
+			u := *r.URL
+			u.RawQuery = query.Encode()
+
+			ctx := context.WithValue(r.Context(), systemProfileContextKey{}, filters)
+			r = r.WithContext(ctx)
+			r.URL = &u
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+func systemProfileFilters(ctx context.Context) []systemProfileFilter {
+	filters, _ := ctx.Value(systemProfileContextKey{}).([]systemProfileFilter)
+	return filters
+}
+
+// filterSystemProfile keeps the hosts of ih whose system profile matches all the filters
+func filterSystemProfile(q *gorm.DB, filters []systemProfileFilter) *gorm.DB {
+	for _, f := range filters {
+		path := pq.Array(f.Path)
+
+		switch f.Operator {
+		case "eq":
+			q = q.Where("ih.system_profile #>> ? = ?", path, f.Value)
+		case "neq":
+			q = q.Where("ih.system_profile #>> ? != ?", path, f.Value)
+		case "gt", "gte", "lt", "lte":
+			// fields which do not hold a number do not match instead of failing the cast
+			number, _ := strconv.ParseFloat(f.Value, 64)
+			comparison := map[string]string{"gt": ">", "gte": ">=", "lt": "<", "lte": "<="}[f.Operator]
+			condition := fmt.Sprintf(
+				"CASE WHEN jsonb_typeof(ih.system_profile #> ?) = 'number' THEN (ih.system_profile #>> ?)::numeric END %s ?",
+				comparison)
+			q = q.Where(condition, path, path, number)
+		case "in":
+			q = q.Where("ih.system_profile #>> ? IN ?", path, strings.Split(f.Value, ","))
+		case "contains":
+			doc, _ := json.Marshal([]string{f.Value})
+			q = q.Where("ih.system_profile #> ? @> ?::jsonb", path, string(doc))
+		case "nil":
+			q = q.Where("ih.system_profile #> ? IS NULL", path)
+		case "not_nil":
+			q = q.Where("ih.system_profile #> ? IS NOT NULL", path)
+		}
+	}
+	return q
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSystemProfileFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		param    string
+		value    string
+		path     []string
+		operator string
+		wantErr  bool
+	}{
+		{name: "eq by default", param: "filter[system_profile][arch]", value: "x86_64", path: []string{"arch"}, operator: "eq"},
+		{name: "operator", param: "filter[system_profile][arch][neq]", value: "x86_64", path: []string{"arch"}, operator: "neq"},
+		{
+			name:     "nested field",
+			param:    "filter[system_profile][operating_system][major][gte]",
+			value:    "8",
+			path:     []string{"operating_system", "major"},
+			operator: "gte",
+		},
+		{name: "contains", param: "filter[system_profile][sap_sids][contains]", value: "ABC", path: []string{"sap_sids"}, operator: "contains"},
+		{name: "field named like an operator", param: "filter[system_profile][nil]", value: "x", path: []string{"nil"}, operator: "eq"},
+		{name: "decimal number", param: "filter[system_profile][cores][lt]", value: "1.5", path: []string{"cores"}, operator: "lt"},
+		{name: "not a number", param: "filter[system_profile][cores][gt]", value: "many", wantErr: true},
+		{name: "invalid segment", param: "filter[system_profile][operating-system]", value: "RHEL", wantErr: true},
+		{name: "trailing text", param: "filter[system_profile][arch]x", value: "x86_64", wantErr: true},
+		{name: "no field", param: "filter[system_profile]", value: "x", wantErr: true},
+		{name: "only the last segment is an operator", param: "filter[system_profile][arch][eq][neq]", value: "x", path: []string{"arch", "eq"}, operator: "neq"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseSystemProfileFilter(tt.param, tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %s=%s, got %+v", tt.param, tt.value, filter)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(filter.Path, tt.path) || filter.Operator != tt.operator {
+				t.Fatalf("expected %v %s, got %v %s", tt.path, tt.operator, filter.Path, filter.Operator)
+			}
+			if filter.Param != tt.param || filter.Value != tt.value {
+				t.Fatalf("expected the parameter to be kept as given, got %s=%s", filter.Param, filter.Value)
+			}
+		})
+	}
+}
+
+func TestParseSystemProfileFilters(t *testing.T) {
+	query := map[string][]string{
+		"filter[system_profile][operating_system][major][gte]": {"8"},
+		"filter[system_profile][arch]":                         {"x86_64", "aarch64"},
+		"sort_key":                                             {"name"},
+	}
+
+	filters, err := parseSystemProfileFilters(query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	params := make([]string, 0, len(filters))
+	for _, f := range filters {
+		params = append(params, f.Param+"="+f.Value)
+	}
+	want := []string{
+		"filter[system_profile][arch]=x86_64",
+		"filter[system_profile][arch]=aarch64",
+		"filter[system_profile][operating_system][major][gte]=8",
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Fatalf("expected %v, got %v", want, params)
+	}
+
+	query["filter[system_profile][cores][gt]"] = []string{"many"}
+	if _, err := parseSystemProfileFilters(query); err == nil {
+		t.Fatal("expected an error when one of the filters is invalid")
+	}
+}
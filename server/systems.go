@@ -0,0 +1,225 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"gorm.io/gorm"
+)
+
+const systemsPath = "/content/systems"
+
+var systemSortColumns = map[string]string{
+	"inventory_id":                   "sp.inventory_id",
+	"display_name":                   "sp.display_name",
+	"packages_installed":             "sp.packages_installed",
+	"packages_updatable":             "sp.packages_updatable",
+	"installable_advisory_count":     "sp.installable_advisory_count_cache",
+	"installable_advisory_enh_count": "sp.installable_advisory_enh_count_cache",
+	"installable_advisory_bug_count": "sp.installable_advisory_bug_count_cache",
+	"installable_advisory_sec_count": "sp.installable_advisory_sec_count_cache",
+	"stale":                          "sp.stale",
+	"stale_timestamp":                "sp.stale_timestamp",
+	"stale_warning_timestamp":        "sp.stale_warning_timestamp",
+	"culled_timestamp":               "sp.culled_timestamp",
+	"third_party":                    "sp.third_party",
+	"satellite_managed":              "sp.satellite_managed",
+	"last_upload":                    "sp.last_upload",
+}
+
+// system_platform columns of a SystemItem
+const systemColumns = `
+	sp.id,
+	sp.rh_account_id,
+	sp.inventory_id,
+	sp.display_name,
+	sp.packages_installed,
+	sp.packages_updatable,
+	sp.installable_advisory_count_cache,
+	sp.installable_advisory_enh_count_cache,
+	sp.installable_advisory_bug_count_cache,
+	sp.installable_advisory_sec_count_cache,
+	sp.stale,
+	sp.stale_timestamp,
+	sp.stale_warning_timestamp,
+	sp.culled_timestamp,
+	sp.third_party,
+	sp.satellite_managed,
+	sp.last_upload
+`
+
+// SystemItem is a row of the system list
+type SystemItem struct {
+	InventoryID                 string     `json:"inventory_id"`
+	DisplayName                 string     `json:"display_name"`
+	PackagesInstalled           int        `json:"packages_installed"`
+	PackagesUpdatable           int        `json:"packages_updatable"`
+	InstallableAdvisoryCount    int        `json:"installable_advisory_count"`
+	InstallableAdvisoryEnhCount int        `json:"installable_advisory_enh_count"`
+	InstallableAdvisoryBugCount int        `json:"installable_advisory_bug_count"`
+	InstallableAdvisorySecCount int        `json:"installable_advisory_sec_count"`
+	Stale                       bool       `json:"stale"`
+	StaleTimestamp              *time.Time `json:"stale_timestamp"`
+	StaleWarningTimestamp       *time.Time `json:"stale_warning_timestamp"`
+	CulledTimestamp             *time.Time `json:"culled_timestamp"`
+	ThirdParty                  bool       `json:"third_party"`
+	SatelliteManaged            bool       `json:"satellite_managed"`
+	LastUpload                  *time.Time `json:"last_upload"`
+}
+
+func newSystemItem(sp cachecontent.SystemPlatform) SystemItem {
+	return SystemItem{
+		InventoryID:                 sp.InventoryID,
+		DisplayName:                 sp.DisplayName,
+		PackagesInstalled:           sp.PackagesInstalled,
+		PackagesUpdatable:           sp.PackagesUpdatable,
+		InstallableAdvisoryCount:    sp.AdvisoryCountCache,
+		InstallableAdvisoryEnhCount: sp.AdvisoryEnhCountCache,
+		InstallableAdvisoryBugCount: sp.AdvisoryBugCountCache,
+		InstallableAdvisorySecCount: sp.AdvisorySecCountCache,
+		Stale:                       sp.Stale,
+		StaleTimestamp:              sp.StaleTimestamp,
+		StaleWarningTimestamp:       sp.StaleWarningTimestamp,
+		CulledTimestamp:             sp.CulledTimestamp,
+		ThirdParty:                  sp.ThirdParty,
+		SatelliteManaged:            sp.SatelliteManaged,
+		LastUpload:                  sp.LastUpload,
+	}
+}
+
+func newSystemItems(rows []cachecontent.SystemPlatform) []SystemItem {
+	items := make([]SystemItem, 0, len(rows))
+	for _, sp := range rows {
+		items = append(items, newSystemItem(sp))
+	}
+	return items
+}
+
+type SystemsPayload struct {
+	Data  []SystemItem `json:"data"`
+	Meta  ListMeta     `json:"meta"`
+	Links ListLinks    `json:"links"`
+}
+
+func (p SystemsPayload) VisitGetContentSystemsResponse(w http.ResponseWriter) error {
+	return writeJSON(w, p)
+}
+
+type systemsOptions struct {
+	listOptions
+	Matches          string
+	Stale            *bool
+	ThirdParty       *bool
+	SatelliteManaged *bool
+}
+
+func getSystemsOptions(params api.GetContentSystemsParams) (systemsOptions, error) {
+	list, err := getListOptions(params.Page, params.Limit, params.SortKey, params.SortOrder, systemSortColumns, "display_name")
+	if err != nil {
+		return systemsOptions{}, err
+	}
+
+	opts := systemsOptions{
+		listOptions:      list,
+		Stale:            params.Stale,
+		ThirdParty:       params.ThirdParty,
+		SatelliteManaged: params.SatelliteManaged,
+	}
+	if params.Matches != nil && *params.Matches != "" {
+		opts.Matches = *params.Matches
+		opts.Filter["matches"] = opts.Matches
+	}
+	if opts.Stale != nil {
+		opts.Filter["stale"] = strconv.FormatBool(*opts.Stale)
+	}
+	if opts.ThirdParty != nil {
+		opts.Filter["third_party"] = strconv.FormatBool(*opts.ThirdParty)
+	}
+	if opts.SatelliteManaged != nil {
+		opts.Filter["satellite_managed"] = strconv.FormatBool(*opts.SatelliteManaged)
+	}
+
+	return opts, nil
+}
+
+func (c *PreFilterServer) GetContentSystems(ctx context.Context, request api.GetContentSystemsRequestObject) (api.GetContentSystemsResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentSystems")
+	defer span.End()
+
+	opts, err := getSystemsOptions(request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+	opts.setSystemProfile(systemProfileFilters(ctx))
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	hostIDs, err := c.lookupHostIDs(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return systems(accountId, hostIDsFilter(hostIDs), opts)
+}
+
+func (c *BaselineServer) GetContentSystems(ctx context.Context, request api.GetContentSystemsRequestObject) (api.GetContentSystemsResponseObject, error) {
+	opts, err := getSystemsOptions(request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+	opts.setSystemProfile(systemProfileFilters(ctx))
+
+	_, accountId, _ := getIdentityFromContext(ctx)
+
+	ctx, span := c.Tracer.Start(ctx, "GetContentSystems")
+	defer span.End()
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+	defer pgSpan.End()
+
+	return systems(accountId, ungroupedHostsFilter, opts)
+}
+
+// systems lists the systems of the account passing the host filter
+func systems(accID int64, filter hostFilter, opts systemsOptions) (SystemsPayload, error) {
+	var total int64
+	var rows []cachecontent.SystemPlatform
+
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		q := tx.Table("system_platform sp").
+			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
+			Where("sp.rh_account_id = ?", accID)
+		q = filterSystemProfile(filter(q), opts.SystemProfile)
+
+		if opts.Matches != "" {
+			q = q.Where("sp.display_name ILIKE ?", "%"+escapeLike(opts.Matches)+"%")
+		}
+		if opts.Stale != nil {
+			q = q.Where("sp.stale = ?", *opts.Stale)
+		}
+		if opts.ThirdParty != nil {
+			q = q.Where("sp.third_party = ?", *opts.ThirdParty)
+		}
+		if opts.SatelliteManaged != nil {
+			q = q.Where("sp.satellite_managed = ?", *opts.SatelliteManaged)
+		}
+
+		return opts.fetchPage(q, systemColumns, "sp.id", &total, &rows)
+	})
+	if err != nil {
+		return SystemsPayload{}, databaseError(err, "failed to get systems")
+	}
+
+	return SystemsPayload{
+		Data:  newSystemItems(rows),
+		Meta:  opts.meta(total),
+		Links: opts.links(systemsPath, total),
+	}, nil
+}
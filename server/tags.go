@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// hostTag is a tag filter in the console syntax namespace/key=value, namespace and value are optional.
+// Parts which are left out match any namespace or value.
+type hostTag struct {
+	Namespace *string `json:"namespace,omitempty"`
+	Key       string  `json:"key"`
+	Value     *string `json:"value,omitempty"`
+}
+
+func parseHostTag(s string) (hostTag, error) {
+	tag := hostTag{}
+
+	nsKey := s
+	if i := strings.Index(s, "="); i >= 0 {
+		value := s[i+1:]
+		tag.Value = &value
+		nsKey = s[:i]
+	}
+
+	tag.Key = nsKey
+	if i := strings.Index(nsKey, "/"); i >= 0 {
+		namespace := nsKey[:i]
+		tag.Namespace = &namespace
+		tag.Key = nsKey[i+1:]
+	}
+
+	if tag.Key == "" {
+		return tag, errors.Errorf("invalid tag %q, expected namespace/key=value", s)
+	}
+	return tag, nil
+}
+
+func parseHostTags(tags *[]string) ([]hostTag, error) {
+	if tags == nil {
+		return nil, nil
+	}
+
+	parsed := make([]hostTag, 0, len(*tags))
+	for _, s := range *tags {
+		tag, err := parseHostTag(s)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, tag)
+	}
+	return parsed, nil
+}
+
+// filterHostTags keeps the hosts of ih having all the tags. The tags go into a single containment predicate
+// which the GIN (jsonb_path_ops) index on tags can serve.
+func filterHostTags(q *gorm.DB, tags []hostTag) *gorm.DB {
+	if len(tags) == 0 {
+		return q
+	}
+
+	doc, _ := json.Marshal(tags)
+	return q.Where("ih.tags @> ?::jsonb", string(doc))
+}
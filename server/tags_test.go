@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseHostTag(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		tag     string
+		want    hostTag
+		wantErr bool
+	}{
+		{name: "namespace, key and value", tag: "insights/env=prod", want: hostTag{Namespace: str("insights"), Key: "env", Value: str("prod")}},
+		{name: "key only", tag: "env", want: hostTag{Key: "env"}},
+		{name: "key and value", tag: "env=prod", want: hostTag{Key: "env", Value: str("prod")}},
+		{name: "empty value", tag: "env=", want: hostTag{Key: "env", Value: str("")}},
+		{name: "empty namespace", tag: "/env", want: hostTag{Namespace: str(""), Key: "env"}},
+		{name: "value with separators", tag: "ns/url=a/b=c", want: hostTag{Namespace: str("ns"), Key: "url", Value: str("a/b=c")}},
+		{name: "empty", tag: "", wantErr: true},
+		{name: "no key", tag: "ns/=prod", wantErr: true},
+		{name: "value only", tag: "=prod", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := parseHostTag(tt.tag)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q, got %+v", tt.tag, tag)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tag, tt.want) {
+				got, _ := json.Marshal(tag)
+				want, _ := json.Marshal(tt.want)
+				t.Fatalf("expected %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestParseHostTags(t *testing.T) {
+	if tags, err := parseHostTags(nil); err != nil || tags != nil {
+		t.Fatalf("expected no tags without the parameter, got %v, %v", tags, err)
+	}
+
+	if _, err := parseHostTags(&[]string{"env=prod", "ns/"}); err == nil {
+		t.Fatal("expected an error when one of the tags is invalid")
+	}
+
+	tags, err := parseHostTags(&[]string{"env=prod", "ns/role"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tags) != 2 || tags[0].Key != "env" || tags[1].Key != "role" {
+		t.Fatalf("expected the tags in the order given, got %+v", tags)
+	}
+}
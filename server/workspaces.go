@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	e "errors"
+	"io"
+	"strings"
+	"sync"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/merlante/inventory-access-poc/api"
+	"github.com/merlante/inventory-access-poc/cachecontent"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+)
+
+// workspace permissions which make up inventory/host#read
+var hostReadWorkspacePermissions = []string{
+	"inventory_hosts_read",
+	"inventory_hosts_all",
+	"inventory_all_read",
+	"inventory_all_all",
+}
+
+// WorkspaceServer looks up the workspaces whose hosts the user can read instead of the hosts themselves and
+// restricts the package list to the hosts of those inventory groups. The other endpoints are answered like in the
+// pre-filter experiment.
+type WorkspaceServer struct {
+	PreFilterServer
+}
+
+func (c *WorkspaceServer) GetContentPackages(ctx context.Context, request api.GetContentPackagesRequestObject) (api.GetContentPackagesResponseObject, error) {
+	ctx, span := c.Tracer.Start(ctx, "GetContentPackages")
+	defer span.End()
+
+	opts, err := getPackageListOptions(ctx, request.Params)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	user, accountId, _ := getIdentityFromContext(ctx)
+
+	workspaces, err := c.lookupWorkspaces(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("workspaces", len(workspaces)))
+
+	ungrouped, err := c.ungroupedWorkspace(ctx, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, pgSpan := c.Tracer.Start(ctx, "Postgres query")
+
+	page := packagePage{}
+	err = cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		q := workspacesFilter(ungrouped, workspaces)(packageAggregate(tx, accountId, opts))
+
+		return fetchPackagePage(tx, q, opts, &page)
+	})
+	if err != nil {
+		return nil, databaseError(err, "failed to get counts")
+	}
+
+	packages, err := GetPackagesPayload(page, opts)
+
+	pgSpan.End()
+
+	return packages, err
+}
+
+// lookupWorkspaces asks SpiceDB for the ids of the workspaces the user can read the hosts of. A workspace is
+// granted to the user through any of the permissions inventory/host#read is made of, the permissions are looked up
+// at the same time.
+func (c *WorkspaceServer) lookupWorkspaces(ctx context.Context, user string) ([]string, error) {
+	_, spiceSpan := c.Tracer.Start(ctx, "SpiceDB workspace pre-filter call")
+	defer spiceSpan.End()
+
+	g, gctx := errgroup.WithContext(ctx)
+	results := make([][]string, len(hostReadWorkspacePermissions))
+	for i, permission := range hostReadWorkspacePermissions {
+		i, permission := i, permission
+		g.Go(func() error {
+			ids, err := lookupResourceIDs(gctx, c.SpicedbClient, &v1.LookupResourcesRequest{
+				ResourceObjectType: "workspace",
+				Permission:         permission,
+				Subject: &v1.SubjectReference{
+					Object: &v1.ObjectReference{
+						ObjectType: "user",
+						ObjectId:   user,
+					},
+				},
+			})
+			results[i] = ids
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+	for _, ids := range results {
+		for _, id := range ids {
+			found[id] = true
+		}
+	}
+
+	workspaces := make([]string, 0, len(found))
+	for id := range found {
+		workspaces = append(workspaces, id)
+	}
+	return workspaces, nil
+}
+
+// ungroupedWorkspaces caches the ungrouped workspace of each account by account id, it does not change
+var ungroupedWorkspaces sync.Map
+
+// ungroupedWorkspace gives the workspace the ungrouped hosts of the account are in, read from the workspace
+// relationship of one of them. It is empty when the account has no ungrouped hosts.
+func (c *WorkspaceServer) ungroupedWorkspace(ctx context.Context, accID int64) (string, error) {
+	if workspace, found := ungroupedWorkspaces.Load(accID); found {
+		return workspace.(string), nil
+	}
+
+	var hostIDs []string
+	err := cachecontent.WithReadReplicaTx(func(tx *gorm.DB) error {
+		q := tx.Table("system_platform sp").
+			Joins("JOIN inventory.hosts ih ON sp.inventory_id = ih.id").
+			Where("sp.rh_account_id = ?", accID)
+
+		return ungroupedHostsFilter(q).Limit(1).Pluck("ih.id", &hostIDs).Error
+	})
+	if err != nil {
+		return "", databaseError(err, "failed to get an ungrouped host")
+	}
+	if len(hostIDs) == 0 {
+		return "", nil
+	}
+
+	rrClient, err := c.SpicedbClient.ReadRelationships(ctx, &v1.ReadRelationshipsRequest{
+		RelationshipFilter: &v1.RelationshipFilter{
+			ResourceType:       "inventory/host",
+			OptionalResourceId: hostIDs[0],
+			OptionalRelation:   "workspace",
+		},
+	})
+	if err != nil {
+		return "", spicedbError(err)
+	}
+
+	workspace := ""
+	for {
+		next, err := rrClient.Recv()
+		if e.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", spicedbError(err)
+		}
+		workspace = next.GetRelationship().GetSubject().GetObject().GetObjectId()
+	}
+
+	if workspace != "" {
+		ungroupedWorkspaces.Store(accID, workspace)
+	}
+	return workspace, nil
+}
+
+// workspacesFilter keeps the hosts in the workspaces. The ungrouped workspace holds the hosts without a group, any
+// other workspace is the inventory group of the same id. Each group is matched with its own containment predicate
+// so that the groups index of inventory.hosts can be used.
+func workspacesFilter(ungrouped string, workspaces []string) hostFilter {
+	var conditions []string
+	var args []interface{}
+	for _, workspace := range workspaces {
+		if workspace == ungrouped {
+			conditions = append(conditions, "ih.groups = '[]'")
+			continue
+		}
+
+		doc, _ := json.Marshal([]map[string]string{{"id": workspace}})
+		conditions = append(conditions, "ih.groups @> ?::jsonb")
+		args = append(args, string(doc))
+	}
+
+	return func(q *gorm.DB) *gorm.DB {
+		if len(conditions) == 0 {
+			return q.Where("false")
+		}
+		return q.Where(strings.Join(conditions, " OR "), args...)
+	}
+}